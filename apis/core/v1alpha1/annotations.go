@@ -52,4 +52,81 @@ const (
 	// injected by POD IRSA, to decide in which region the resources should be
 	// created.
 	AnnotationDefaultRegion = AnnotationPrefix + "default-region"
+	// AnnotationDefaultResourceTags is an annotation whose value is a
+	// comma-separated list of key=value pairs, e.g. "team=storage,env=dev",
+	// of tags that should be added to all AWS resources created for CRs in the
+	// namespace. If this annotation is set on a namespace, these tags are
+	// merged with the tags supplied to the ACK service controller's
+	// --resource-tags flag, and the values in the annotation take precedence.
+	// Tags in a CR's Spec always take precedence over these default tags.
+	AnnotationDefaultResourceTags = AnnotationPrefix + "default-resource-tags"
+	// AnnotationEndpointURL is an annotation whose value is the URL of the
+	// AWS service API endpoint that the ACK service controller should call to
+	// manage the resources for CRs in the namespace, e.g.
+	// "http://localstack:4566". If this annotation is set on a namespace, it
+	// takes precedence over the ACK service controller's --endpoint-url and
+	// --service-endpoint-urls flags. This is intended for pointing the ACK
+	// service controller at local stand-ins for AWS service APIs in test
+	// namespaces.
+	AnnotationEndpointURL = AnnotationPrefix + "endpoint-url"
+	// AnnotationCredentialsProvider is an annotation whose value describes
+	// where the ACK service controller should get the AWS credentials it uses
+	// to manage the resources for CRs in the namespace. If this annotation is
+	// set on a namespace, it takes precedence over the ACK service
+	// controller's --credentials-provider flag. The value is one of:
+	//
+	// * "default": the default AWS credential chain of the ACK service
+	//   controller
+	// * "secret:[<namespace>/]<name>": the static access key in the
+	//   `aws_access_key_id`, `aws_secret_access_key` and optional
+	//   `aws_session_token` keys of a Secret. The Secret is in the CR's
+	//   namespace unless another namespace is specified.
+	// * "profile:<name>": the named profile in the ACK service controller's
+	//   shared credentials file
+	// * "web-identity:<role ARN>": the credentials for the IAM Role with the
+	//   supplied ARN, assumed with the ACK service controller's web identity
+	//   token file
+	AnnotationCredentialsProvider = AnnotationPrefix + "credentials-provider"
+	// AnnotationReconcilePaused is an annotation whose value is "true" if the
+	// ACK service controller should stop managing the backend AWS service API
+	// resources for a CR, or for all CRs in a namespace. If this annotation is
+	// set to "true" on a CR or a namespace, the ACK service controller makes
+	// no calls to create, update or delete the backend AWS service API
+	// resource, and only reads its state if the controller is configured to
+	// do so. The CR keeps its finalizer, so deleting the CR while
+	// reconciliation is paused leaves the backend AWS service API resource
+	// untouched until the annotation is removed.
+	AnnotationReconcilePaused = AnnotationPrefix + "reconcile-paused"
+	// AnnotationDriftPolicy is an annotation whose value tells the ACK
+	// service controller what to do when the backend AWS service API resource
+	// for a CR, or for all CRs in a namespace, differs from the desired state
+	// in the CR's Spec, e.g. because it was modified outside of Kubernetes.
+	// The annotation on a CR takes precedence over the annotation on its
+	// namespace. One of:
+	//
+	// * "enforce", the default: the resource is updated to match the
+	//   desired state
+	// * "report": the resource is left as is and the CR's ACK.Drifted
+	//   condition lists the fields that differ. Changes to the CR's Spec
+	//   aren't applied to the resource either.
+	AnnotationDriftPolicy = AnnotationPrefix + "drift-policy"
+	// AnnotationDependsOn is an annotation whose value is a comma-separated
+	// list of other CRs, in the form <kind>/<name>, e.g.
+	// "Deployment/my-deployment", that the CR depends on. The CRs are in the
+	// CR's namespace and are of kinds of the same AWS service API. If this
+	// annotation is set on a CR, the ACK service controller doesn't create or
+	// update the backend AWS service API resource for the CR until the
+	// ACK.ResourceSynced condition of all these CRs is True.
+	AnnotationDependsOn = AnnotationPrefix + "depends-on"
+)
+
+const (
+	// DriftPolicyEnforce is the value of the drift policy annotation that
+	// makes the ACK service controller update resources that drifted from
+	// their desired state
+	DriftPolicyEnforce = "enforce"
+	// DriftPolicyReport is the value of the drift policy annotation that
+	// makes the ACK service controller only report resources that drifted
+	// from their desired state
+	DriftPolicyReport = "report"
 )
@@ -26,6 +26,31 @@ const (
 	// ConditionTypeResourceSynced indicates the state of the resource in the
 	// backend service is in sync with the ACK service controller
 	ConditionTypeResourceSynced ConditionType = "ACK.ResourceSynced"
+	// ConditionTypeTerminal indicates the backend AWS service API resource
+	// is in a state from which it cannot recover without intervention from
+	// the Kubernetes user, and that the ACK service controller will not
+	// retry reconciling the resource until the CR is changed
+	ConditionTypeTerminal ConditionType = "ACK.Terminal"
+	// ConditionTypeDeleting indicates the CR has been deleted and the ACK
+	// service controller is waiting for the backend AWS service API to finish
+	// deleting the resource before allowing the Kubernetes API server to
+	// remove the CR
+	ConditionTypeDeleting ConditionType = "ACK.Deleting"
+	// ConditionTypeReconcilePaused indicates that the ACK service controller
+	// is not managing the backend AWS service API resource for the CR because
+	// the CR or its namespace has the services.k8s.aws/reconcile-paused
+	// annotation
+	ConditionTypeReconcilePaused ConditionType = "ACK.ReconcilePaused"
+	// ConditionTypeDrifted indicates that the backend AWS service API
+	// resource differs from the desired state in the CR's Spec and that the
+	// ACK service controller leaves it as is because the CR or its namespace
+	// has the services.k8s.aws/drift-policy annotation set to "report"
+	ConditionTypeDrifted ConditionType = "ACK.Drifted"
+	// ConditionTypeWaitingForDependencies indicates that the ACK service
+	// controller doesn't create or update the backend AWS service API
+	// resource for the CR until the CRs listed in its
+	// services.k8s.aws/depends-on annotation are synced
+	ConditionTypeWaitingForDependencies ConditionType = "ACK.WaitingForDependencies"
 )
 
 // Condition is the common struct used by all CRDs managed by ACK service
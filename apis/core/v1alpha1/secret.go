@@ -0,0 +1,29 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+// SecretKeyReference identifies a single key in a Kubernetes Secret. CR Spec
+// fields containing sensitive values, e.g. passwords and auth tokens, refer
+// to the Secret holding the value instead of containing the value itself.
+// The ACK service controller reads the value from the Secret when it builds
+// the request payloads for the backend AWS service API and watches the Secret
+// so that changing its value updates the AWS resource. The Secret must be in
+// the namespace of the CR referring to it, so that CRs can't expose the
+// Secrets of namespaces their authors have no access to.
+type SecretKeyReference struct {
+	// Name is the name of the Secret
+	Name string `json:"name"`
+	// Key is the key of the value in the Secret's data
+	Key string `json:"key"`
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+const (
+	// TagKeyPrefix is the prefix for the keys of all tags that ACK service
+	// controllers add to the AWS resources they create
+	TagKeyPrefix = "services.k8s.aws/"
+	// TagKeyManaged is the key of a tag, with a value of "true", that ACK
+	// service controllers add to all AWS resources they create. It allows
+	// AWS users to identify the resources managed by a Kubernetes cluster.
+	TagKeyManaged = TagKeyPrefix + "managed"
+	// TagKeyNamespace is the key of a tag whose value is the Kubernetes
+	// namespace of the CR representing the AWS resource
+	TagKeyNamespace = TagKeyPrefix + "namespace"
+	// TagKeyName is the key of a tag whose value is the name of the CR
+	// representing the AWS resource
+	TagKeyName = TagKeyPrefix + "name"
+	// TagKeyClusterID is the key of a tag whose value is the identifier of
+	// the Kubernetes cluster containing the CR representing the AWS
+	// resource, supplied to the service controller's --cluster-id flag. It
+	// distinguishes the resources of clusters sharing an AWS account and
+	// region.
+	TagKeyClusterID = TagKeyPrefix + "cluster-id"
+)
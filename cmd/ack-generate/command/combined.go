@@ -0,0 +1,82 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/aws-controllers-k8s/pkg/generate"
+)
+
+var (
+	optCombinedOutputPath string
+)
+
+var combinedCmd = &cobra.Command{
+	Use:   "combined <service> [<service>...]",
+	Short: "Generates the main.go file of a single controller binary hosting the service controllers of several services",
+	RunE:  generateCombined,
+}
+
+func init() {
+	combinedCmd.PersistentFlags().StringVarP(
+		&optCombinedOutputPath, "output", "o", "", "path to directory to create generated main.go file in. Defaults to "+optServicesDir+"/../cmd/ack-controller",
+	)
+	rootCmd.AddCommand(combinedCmd)
+}
+
+// generateCombined generates the main.go file of a controller binary hosting
+// the service controllers of the supplied services. The service controllers
+// must already have been generated with the controller command.
+func generateCombined(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("please specify the service aliases of the AWS service APIs to combine")
+	}
+	svcAliases := make([]string, 0, len(args))
+	for _, arg := range args {
+		svcAlias := strings.ToLower(arg)
+		pkgControllerPath := filepath.Join(optServicesDir, svcAlias, "pkg", "controller")
+		if _, err := os.Stat(pkgControllerPath); err != nil {
+			return fmt.Errorf(
+				"service controller for %s not found in %s. Please generate it with the controller command",
+				svcAlias, pkgControllerPath,
+			)
+		}
+		svcAliases = append(svcAliases, svcAlias)
+	}
+	if optCombinedOutputPath == "" {
+		optCombinedOutputPath = filepath.Join(optServicesDir, "..", "cmd", "ack-controller")
+	}
+
+	b, err := generate.GenerateCombinedControllerMainFile(optTemplatesDir, svcAliases)
+	if err != nil {
+		return err
+	}
+	if optDryRun {
+		fmt.Println("============================= main.go ======================================")
+		fmt.Println(strings.TrimSpace(b.String()))
+		return nil
+	}
+	if _, err := ensureDir(optCombinedOutputPath); err != nil {
+		return err
+	}
+	path := filepath.Join(optCombinedOutputPath, "main.go")
+	return ioutil.WriteFile(path, b.Bytes(), 0666)
+}
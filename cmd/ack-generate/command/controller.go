@@ -95,6 +95,9 @@ func generateController(cmd *cobra.Command, args []string) error {
 	if err = writeControllerMainGo(g, crds); err != nil {
 		return err
 	}
+	if err = writeServiceControllerGo(g); err != nil {
+		return err
+	}
 	if err = writeResourcePackage(g, crds); err != nil {
 		return err
 	}
@@ -118,6 +121,24 @@ func writeControllerMainGo(g *generate.Generator, crds []*ackmodel.CRD) error {
 	return ioutil.WriteFile(path, b.Bytes(), 0666)
 }
 
+func writeServiceControllerGo(g *generate.Generator) error {
+	b, err := g.GenerateServiceControllerFile()
+	if err != nil {
+		return err
+	}
+	if optDryRun {
+		fmt.Println("============================= pkg/controller/controller.go ======================================")
+		fmt.Println(strings.TrimSpace(b.String()))
+		return nil
+	}
+	pkgControllerPath := filepath.Join(optControllerOutputPath, "pkg", "controller")
+	if _, err := ensureDir(pkgControllerPath); err != nil {
+		return err
+	}
+	path := filepath.Join(pkgControllerPath, "controller.go")
+	return ioutil.WriteFile(path, b.Bytes(), 0666)
+}
+
 func writeResourcePackage(g *generate.Generator, crds []*ackmodel.CRD) error {
 	targets := []string{
 		"descriptor",
@@ -126,6 +147,7 @@ func writeResourcePackage(g *generate.Generator, crds []*ackmodel.CRD) error {
 		"manager_factory",
 		"resource",
 		"sdk",
+		"webhook",
 	}
 	for _, crd := range crds {
 		pkgCRDResourcePath := filepath.Join(pkgResourcePath, crd.Names.Snake)
@@ -171,6 +193,7 @@ func writeConfigDirs(g *generate.Generator) error {
 	configDefaultPath := filepath.Join(optControllerOutputPath, "config", "default")
 	configControllerPath := filepath.Join(optControllerOutputPath, "config", "controller")
 	configRBACPath := filepath.Join(optControllerOutputPath, "config", "rbac")
+	configWebhookPath := filepath.Join(optControllerOutputPath, "config", "webhook")
 	if !optDryRun {
 		if _, err := ensureDir(configDefaultPath); err != nil {
 			return err
@@ -181,13 +204,22 @@ func writeConfigDirs(g *generate.Generator) error {
 		if _, err := ensureDir(configRBACPath); err != nil {
 			return err
 		}
+		if _, err := ensureDir(configWebhookPath); err != nil {
+			return err
+		}
 	}
 	targets := []string{
 		"controller/deployment",
 		"controller/kustomization",
 		"default/kustomization",
+		"default/webhook_patch",
 		"rbac/cluster-role-binding",
 		"rbac/kustomization",
+		"webhook/certificate",
+		"webhook/kustomization",
+		"webhook/kustomizeconfig",
+		"webhook/manifests",
+		"webhook/service",
 	}
 	for _, target := range targets {
 		b, err := g.GenerateConfigYAMLFile(target)
@@ -111,3 +111,8 @@ func (_m *AWSResource) RuntimeObject() runtime.Object {
 
 	return r0
 }
+
+// SetConditions provides a mock function with given fields: _a0
+func (_m *AWSResource) SetConditions(_a0 []*v1alpha1.Condition) {
+	_m.Called(_a0)
+}
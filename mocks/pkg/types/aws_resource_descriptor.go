@@ -18,6 +18,11 @@ type AWSResourceDescriptor struct {
 	mock.Mock
 }
 
+// Default provides a mock function with given fields: _a0
+func (_m *AWSResourceDescriptor) Default(_a0 types.AWSResource) {
+	_m.Called(_a0)
+}
+
 // Diff provides a mock function with given fields: _a0, _a1
 func (_m *AWSResourceDescriptor) Diff(_a0 types.AWSResource, _a1 types.AWSResource) *compare.Reporter {
 	ret := _m.Called(_a0, _a1)
@@ -140,3 +145,17 @@ func (_m *AWSResourceDescriptor) UpdateCRStatus(_a0 types.AWSResource) (bool, er
 
 	return r0, r1
 }
+
+// Validate provides a mock function with given fields: _a0
+func (_m *AWSResourceDescriptor) Validate(_a0 types.AWSResource) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.AWSResource) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -3,13 +3,16 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
-	corev1 "k8s.io/api/core/v1"
 	manager "sigs.k8s.io/controller-runtime/pkg/manager"
 
 	reconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 )
 
 // AWSResourceReconciler is an autogenerated mock type for the AWSResourceReconciler type
@@ -68,20 +71,20 @@ func (_m *AWSResourceReconciler) Reconcile(_a0 reconcile.Request) (reconcile.Res
 	return r0, r1
 }
 
-// SecretValueFromReference provides a mock function with given fields: _a0
-func (_m *AWSResourceReconciler) SecretValueFromReference(_a0 *corev1.SecretReference) (string, error) {
-	ret := _m.Called(_a0)
+// SecretValueFromReference provides a mock function with given fields: _a0, _a1
+func (_m *AWSResourceReconciler) SecretValueFromReference(_a0 context.Context, _a1 *v1alpha1.SecretKeyReference) (string, error) {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*corev1.SecretReference) string); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1alpha1.SecretKeyReference) string); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*corev1.SecretReference) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *v1alpha1.SecretKeyReference) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,186 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package arn parses, builds and validates Amazon Resource Names (ARNs)
+// stored in the common `ackv1alpha1.AWSResourceName` type.
+package arn
+
+import (
+	"fmt"
+	"strings"
+
+	awsarn "github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+)
+
+const (
+	// DefaultPartition is the partition of the commercial AWS regions
+	DefaultPartition = endpoints.AwsPartitionID
+
+	// Placeholders replaced in ARN templates by FromTemplate
+	PlaceholderPartition = "{partition}"
+	PlaceholderRegion    = "{region}"
+	PlaceholderAccountID = "{account}"
+	PlaceholderName      = "{name}"
+)
+
+// ARN is a parsed Amazon Resource Name, e.g.
+// "arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+type ARN struct {
+	// Partition is the partition the resource is in, e.g. "aws", "aws-cn"
+	// or "aws-us-gov"
+	Partition string
+	// Service is the service namespace, e.g. "ecr"
+	Service string
+	// Region is the region the resource is in. It's empty for global
+	// resources, e.g. S3 buckets and IAM roles.
+	Region string
+	// AccountID is the ID of the AWS account that owns the resource. It's
+	// empty for some resources, e.g. S3 buckets.
+	AccountID string
+	// Resource identifies the resource within the service, and may include
+	// a resource type, e.g. "repository/my-repo"
+	Resource string
+}
+
+// Parse parses the supplied ARN
+func Parse(name ackv1alpha1.AWSResourceName) (ARN, error) {
+	parsed, err := awsarn.Parse(string(name))
+	if err != nil {
+		return ARN{}, err
+	}
+	return ARN{
+		Partition: parsed.Partition,
+		Service:   parsed.Service,
+		Region:    parsed.Region,
+		AccountID: parsed.AccountID,
+		Resource:  parsed.Resource,
+	}, nil
+}
+
+// Validate returns an error if the supplied ARN is malformed or its partition
+// is not a known partition
+func Validate(name ackv1alpha1.AWSResourceName) error {
+	parsed, err := Parse(name)
+	if err != nil {
+		return err
+	}
+	if parsed.Service == "" {
+		return fmt.Errorf("arn: missing service in %q", name)
+	}
+	if parsed.Resource == "" {
+		return fmt.Errorf("arn: missing resource in %q", name)
+	}
+	for _, p := range endpoints.DefaultPartitions() {
+		if p.ID() == parsed.Partition {
+			return nil
+		}
+	}
+	return fmt.Errorf("arn: unknown partition %q in %q", parsed.Partition, name)
+}
+
+// Name returns the ARN as an AWSResourceName
+func (a ARN) Name() ackv1alpha1.AWSResourceName {
+	return ackv1alpha1.AWSResourceName(a.String())
+}
+
+// String returns the string form of the ARN
+func (a ARN) String() string {
+	return awsarn.ARN{
+		Partition: a.Partition,
+		Service:   a.Service,
+		Region:    a.Region,
+		AccountID: a.AccountID,
+		Resource:  a.Resource,
+	}.String()
+}
+
+// Build returns the ARN of the resource identified by the supplied service
+// namespace and resource in the supplied region and account. The partition is
+// worked out from the region.
+func Build(
+	service string,
+	region ackv1alpha1.AWSRegion,
+	accountID ackv1alpha1.AWSAccountID,
+	resource string,
+) ARN {
+	return ARN{
+		Partition: PartitionForRegion(region),
+		Service:   service,
+		Region:    string(region),
+		AccountID: string(accountID),
+		Resource:  resource,
+	}
+}
+
+// PartitionForRegion returns the partition containing the supplied region,
+// e.g. "aws-cn" for "cn-north-1". Regions that aren't in a known partition,
+// including the empty region, are assumed to be in the "aws" partition.
+func PartitionForRegion(region ackv1alpha1.AWSRegion) string {
+	if region == "" {
+		return DefaultPartition
+	}
+	p, found := endpoints.PartitionForRegion(
+		endpoints.DefaultPartitions(), string(region),
+	)
+	if !found {
+		return DefaultPartition
+	}
+	return p.ID()
+}
+
+// FromTemplate returns the ARN built from the supplied template by replacing
+// the {partition}, {region}, {account} and {name} placeholders, and a
+// placeholder for each of the supplied fields, e.g. {ApiId} for the "ApiId"
+// field. The partition is worked out from the region. For example, the
+// template "arn:{partition}:ecr:{region}:{account}:repository/{name}" gives
+// "arn:aws-cn:ecr:cn-north-1:123456789012:repository/my-repo" for the
+// "my-repo" repository in the "cn-north-1" region.
+func FromTemplate(
+	template string,
+	region ackv1alpha1.AWSRegion,
+	accountID ackv1alpha1.AWSAccountID,
+	name string,
+	fields map[string]string,
+) ackv1alpha1.AWSResourceName {
+	oldnew := []string{
+		PlaceholderPartition, PartitionForRegion(region),
+		PlaceholderRegion, string(region),
+		PlaceholderAccountID, string(accountID),
+		PlaceholderName, name,
+	}
+	for field, value := range fields {
+		oldnew = append(oldnew, FieldPlaceholder(field), value)
+	}
+	return ackv1alpha1.AWSResourceName(
+		strings.NewReplacer(oldnew...).Replace(template),
+	)
+}
+
+// FieldPlaceholder returns the placeholder replaced in ARN templates by the
+// value of the supplied field, e.g. "{ApiId}"
+func FieldPlaceholder(field string) string {
+	return "{" + field + "}"
+}
+
+// DefaultTemplate returns the ARN template for resources of the supplied
+// service namespace whose ARNs have no resource type segment, e.g.
+// "arn:{partition}:sns:{region}:{account}:{name}"
+func DefaultTemplate(service string) string {
+	return strings.Join([]string{
+		"arn", PlaceholderPartition, service, PlaceholderRegion,
+		PlaceholderAccountID, PlaceholderName,
+	}, ":")
+}
@@ -0,0 +1,108 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
+)
+
+func TestPartitionForRegion(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("aws", ackarn.PartitionForRegion("us-west-2"))
+	require.Equal("aws-cn", ackarn.PartitionForRegion("cn-northwest-1"))
+	require.Equal("aws-us-gov", ackarn.PartitionForRegion("us-gov-west-1"))
+	require.Equal("aws-iso", ackarn.PartitionForRegion("us-iso-east-1"))
+	// Unknown regions that match a partition's region pattern
+	require.Equal("aws", ackarn.PartitionForRegion("eu-south-9"))
+	require.Equal("aws", ackarn.PartitionForRegion(""))
+}
+
+func TestParseAndBuild(t *testing.T) {
+	require := require.New(t)
+
+	name := ackv1alpha1.AWSResourceName(
+		"arn:aws-cn:ecr:cn-north-1:123456789012:repository/my-repo",
+	)
+	parsed, err := ackarn.Parse(name)
+	require.Nil(err)
+	require.Equal("aws-cn", parsed.Partition)
+	require.Equal("ecr", parsed.Service)
+	require.Equal("cn-north-1", parsed.Region)
+	require.Equal("123456789012", parsed.AccountID)
+	require.Equal("repository/my-repo", parsed.Resource)
+	require.Equal(name, parsed.Name())
+
+	built := ackarn.Build("ecr", "cn-north-1", "123456789012", "repository/my-repo")
+	require.Equal(parsed, built)
+
+	_, err = ackarn.Parse("my-repo")
+	require.NotNil(err)
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(ackarn.Validate("arn:aws:s3:::my-bucket"))
+	require.Nil(ackarn.Validate("arn:aws-us-gov:sns:us-gov-west-1:123456789012:my-topic"))
+	require.NotNil(ackarn.Validate("arn:aws:s3:::"))
+	require.NotNil(ackarn.Validate("arn:aws::us-west-2:123456789012:my-topic"))
+	require.NotNil(ackarn.Validate("arn:aws-mars:sns:mars-1:123456789012:my-topic"))
+	require.NotNil(ackarn.Validate("my-topic"))
+}
+
+func TestFromTemplate(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(
+		ackv1alpha1.AWSResourceName("arn:aws:sns:us-west-2:123456789012:my-topic"),
+		ackarn.FromTemplate(
+			ackarn.DefaultTemplate("sns"), "us-west-2", "123456789012", "my-topic", nil,
+		),
+	)
+	require.Equal(
+		ackv1alpha1.AWSResourceName("arn:aws-cn:ecr:cn-north-1:123456789012:repository/my-repo"),
+		ackarn.FromTemplate(
+			"arn:{partition}:ecr:{region}:{account}:repository/{name}",
+			"cn-north-1", "123456789012", "my-repo", nil,
+		),
+	)
+	require.Equal(
+		ackv1alpha1.AWSResourceName("arn:aws-us-gov:s3:::my-bucket"),
+		ackarn.FromTemplate(
+			"arn:{partition}:s3:::{name}", "us-gov-east-1", "123456789012", "my-bucket", nil,
+		),
+	)
+	require.Equal(
+		ackv1alpha1.AWSResourceName("arn:aws:sns:us-west-2:123456789012:app/GCM/my-app"),
+		ackarn.FromTemplate(
+			"arn:{partition}:sns:{region}:{account}:app/{Platform}/{name}",
+			"us-west-2", "123456789012", "my-app",
+			map[string]string{"Platform": "GCM"},
+		),
+	)
+	require.Equal(
+		ackv1alpha1.AWSResourceName("arn:aws:apigateway:us-west-2::/apis/a1b2c3/routes/d4e5f6"),
+		ackarn.FromTemplate(
+			"arn:{partition}:apigateway:{region}::/apis/{ApiId}/routes/{RouteId}",
+			"us-west-2", "123456789012", "",
+			map[string]string{"ApiId": "a1b2c3", "RouteId": "d4e5f6"},
+		),
+	)
+}
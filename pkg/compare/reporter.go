@@ -42,4 +42,38 @@ func (reporter *Reporter) String() string {
 		diffs = append(diffs, diff.String())
 	}
 	return strings.Join(diffs, "\n")
-}
\ No newline at end of file
+}
+// DifferentAt returns true if there is a difference at, or below, the supplied
+// path, e.g. "Spec.Tags"
+func (reporter *Reporter) DifferentAt(path string) bool {
+	for _, diff := range reporter.Differences {
+		if isAtOrBelow(diff.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// DifferentExcept returns true if there is a difference that is not at, or
+// below, any of the supplied paths
+func (reporter *Reporter) DifferentExcept(paths ...string) bool {
+	for _, diff := range reporter.Differences {
+		excepted := false
+		for _, path := range paths {
+			if isAtOrBelow(diff.Path, path) {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return true
+		}
+	}
+	return false
+}
+
+// isAtOrBelow returns true if the supplied diffPath is the supplied path or a
+// path to a field nested within it
+func isAtOrBelow(diffPath string, path string) bool {
+	return diffPath == path || strings.HasPrefix(diffPath, path+".")
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare
+
+import (
+	"sort"
+)
+
+// DiffTags compares the supplied desired and latest tags and returns the tags
+// that need to be added or updated and the sorted keys of the tags that need
+// to be removed so that the latest tags match the desired tags
+func DiffTags(
+	desired map[string]string,
+	latest map[string]string,
+) (map[string]string, []string) {
+	toAdd := map[string]string{}
+	toRemove := []string{}
+	for k, v := range desired {
+		if lv, found := latest[k]; !found || lv != v {
+			toAdd[k] = v
+		}
+	}
+	for k := range latest {
+		if _, found := desired[k]; !found {
+			toRemove = append(toRemove, k)
+		}
+	}
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
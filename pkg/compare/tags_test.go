@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package compare_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+)
+
+func TestDiffTags(t *testing.T) {
+	require := require.New(t)
+
+	desired := map[string]string{
+		"unchanged": "a",
+		"changed":   "new",
+		"added":     "b",
+	}
+	latest := map[string]string{
+		"unchanged": "a",
+		"changed":   "old",
+		"removed2":  "c",
+		"removed1":  "d",
+	}
+	toAdd, toRemove := ackcompare.DiffTags(desired, latest)
+	require.Equal(map[string]string{"changed": "new", "added": "b"}, toAdd)
+	require.Equal([]string{"removed1", "removed2"}, toRemove)
+
+	toAdd, toRemove = ackcompare.DiffTags(desired, desired)
+	require.Empty(toAdd)
+	require.Empty(toRemove)
+}
@@ -0,0 +1,233 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Class is a category of errors returned by AWS service APIs that callers
+// handle in the same way, whatever the service
+type Class string
+
+const (
+	// ClassUnknown is the class of errors that aren't in any other class
+	ClassUnknown Class = ""
+	// ClassThrottling is the class of errors returned when requests are
+	// being made too quickly
+	ClassThrottling Class = "Throttling"
+	// ClassAccessDenied is the class of errors returned when the caller's
+	// credentials are invalid or don't allow the request
+	ClassAccessDenied Class = "AccessDenied"
+	// ClassNotFound is the class of errors returned when the resource
+	// doesn't exist
+	ClassNotFound Class = "NotFound"
+	// ClassValidation is the class of errors returned when the request is
+	// invalid
+	ClassValidation Class = "Validation"
+	// ClassConflict is the class of errors returned when the request
+	// conflicts with the current state of the resource, e.g. the resource
+	// already exists or is being modified
+	ClassConflict Class = "Conflict"
+	// ClassServiceUnavailable is the class of errors returned when the AWS
+	// service API fails or is temporarily unavailable
+	ClassServiceUnavailable Class = "ServiceUnavailable"
+)
+
+var (
+	// commonCodes contains the error codes that AWS service APIs commonly
+	// return for each class of errors
+	commonCodes = map[string]Class{}
+	// statusCodeClasses maps the HTTP status codes of failed requests to
+	// classes of errors, for errors whose code isn't known
+	statusCodeClasses = map[int]Class{
+		http.StatusBadRequest:          ClassValidation,
+		http.StatusForbidden:           ClassAccessDenied,
+		http.StatusNotFound:            ClassNotFound,
+		http.StatusConflict:            ClassConflict,
+		http.StatusTooManyRequests:     ClassThrottling,
+		http.StatusInternalServerError: ClassServiceUnavailable,
+		http.StatusBadGateway:          ClassServiceUnavailable,
+		http.StatusServiceUnavailable:  ClassServiceUnavailable,
+		http.StatusGatewayTimeout:      ClassServiceUnavailable,
+	}
+	// serviceCodes contains the error codes registered by service
+	// controllers, keyed by API group, e.g. "ecr.services.k8s.aws"
+	serviceCodes     = map[string]map[string]Class{}
+	serviceCodesLock sync.RWMutex
+)
+
+func init() {
+	for class, codes := range map[Class][]string{
+		ClassThrottling: {
+			"Throttling", "ThrottlingException", "ThrottledException",
+			"RequestThrottledException", "TooManyRequestsException",
+			"ProvisionedThroughputExceededException", "RequestLimitExceeded",
+			"BandwidthLimitExceeded", "RequestThrottled", "SlowDown",
+			"PriorRequestNotComplete", "EC2ThrottledException",
+		},
+		ClassAccessDenied: {
+			"AccessDenied", "AccessDeniedException", "UnauthorizedOperation",
+			"UnrecognizedClientException", "InvalidClientTokenId",
+			"AuthFailure", "ExpiredToken", "ExpiredTokenException",
+			"SignatureDoesNotMatch", "NotAuthorized",
+		},
+		ClassNotFound: {
+			"NotFound", "NotFoundException", "ResourceNotFoundException",
+			"NoSuchEntity",
+		},
+		ClassValidation: {
+			"ValidationError", "ValidationException", "InvalidParameter",
+			"InvalidParameterValue", "InvalidParameterValueException",
+			"InvalidParameterCombination", "InvalidParameterException",
+			"InvalidInput", "MissingParameter", "SerializationException",
+		},
+		ClassConflict: {
+			"ConflictException", "ResourceConflictException",
+			"ResourceInUseException", "ConcurrentModificationException",
+			"OperationAborted", "ResourceAlreadyExistsException",
+			"AlreadyExistsException", "EntityAlreadyExists",
+		},
+		ClassServiceUnavailable: {
+			"ServiceUnavailable", "ServiceUnavailableException",
+			"InternalFailure", "InternalError", "InternalServerError",
+			"InternalServiceError", "InternalServerException",
+			"RequestTimeout", "RequestTimeoutException",
+		},
+	} {
+		for _, code := range codes {
+			commonCodes[code] = class
+		}
+	}
+}
+
+// RegisterErrorCodes adds error codes that the AWS service API of the
+// supplied API group returns for the supplied class of errors. Codes
+// registered for a service take precedence over the common codes. Generated
+// service controllers register the codes from the `errors` generator config.
+func RegisterErrorCodes(apiGroup string, class Class, codes ...string) {
+	serviceCodesLock.Lock()
+	defer serviceCodesLock.Unlock()
+	if serviceCodes[apiGroup] == nil {
+		serviceCodes[apiGroup] = map[string]Class{}
+	}
+	for _, code := range codes {
+		serviceCodes[apiGroup][code] = class
+	}
+}
+
+// ResourceError is an error returned while operating on a resource. It adds
+// the kind of the resource and the operation to the error.
+type ResourceError struct {
+	// GroupKind is the kind of the resource
+	GroupKind *metav1.GroupKind
+	// Operation is the operation that failed, e.g. "ReadOne" or "Create"
+	Operation string
+	// Err is the underlying error
+	Err error
+}
+
+// Wrap returns the supplied error wrapped in a ResourceError with the
+// supplied GroupKind and operation, or nil if the error is nil
+func Wrap(err error, gk *metav1.GroupKind, operation string) error {
+	if err == nil {
+		return nil
+	}
+	return &ResourceError{GroupKind: gk, Operation: operation, Err: err}
+}
+
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Operation, e.GroupKind.String(), e.Err)
+}
+
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// Classify returns the class of the supplied error. The error code of an AWS
+// service API error is looked up in the codes registered for the service
+// (when the error is wrapped in a ResourceError), then in the common codes.
+// Errors with unknown codes are classified by the HTTP status code of the
+// failed request, if any.
+func Classify(err error) Class {
+	if err == nil {
+		return ClassUnknown
+	}
+	if errors.Is(err, NotFound) || errors.Is(err, AdoptedResourceNotFound) {
+		return ClassNotFound
+	}
+	awsErr, ok := AWSError(err)
+	if !ok {
+		return ClassUnknown
+	}
+	var resErr *ResourceError
+	if errors.As(err, &resErr) && resErr.GroupKind != nil {
+		serviceCodesLock.RLock()
+		class, found := serviceCodes[resErr.GroupKind.Group][awsErr.Code()]
+		serviceCodesLock.RUnlock()
+		if found {
+			return class
+		}
+	}
+	if class, found := commonCodes[awsErr.Code()]; found {
+		return class
+	}
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		return statusCodeClasses[reqErr.StatusCode()]
+	}
+	return ClassUnknown
+}
+
+// IsThrottling returns true if the supplied error was returned because
+// requests are being made too quickly
+func IsThrottling(err error) bool {
+	return Classify(err) == ClassThrottling
+}
+
+// IsAccessDenied returns true if the supplied error was returned because the
+// caller's credentials are invalid or don't allow the request
+func IsAccessDenied(err error) bool {
+	return Classify(err) == ClassAccessDenied
+}
+
+// IsNotFound returns true if the supplied error was returned because the
+// resource doesn't exist
+func IsNotFound(err error) bool {
+	return Classify(err) == ClassNotFound
+}
+
+// IsValidation returns true if the supplied error was returned because the
+// request is invalid
+func IsValidation(err error) bool {
+	return Classify(err) == ClassValidation
+}
+
+// IsConflict returns true if the supplied error was returned because the
+// request conflicts with the current state of the resource
+func IsConflict(err error) bool {
+	return Classify(err) == ClassConflict
+}
+
+// IsServiceUnavailable returns true if the supplied error was returned
+// because the AWS service API failed or is temporarily unavailable
+func IsServiceUnavailable(err error) bool {
+	return Classify(err) == ClassServiceUnavailable
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+)
+
+func TestClassify(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(ackerr.IsThrottling(nil))
+	assert.False(ackerr.IsThrottling(fmt.Errorf("boom")))
+	assert.True(ackerr.IsNotFound(ackerr.NotFound))
+	assert.True(ackerr.IsNotFound(ackerr.AdoptedResourceNotFound))
+
+	assert.True(ackerr.IsThrottling(awserr.New("ThrottlingException", "", nil)))
+	assert.True(ackerr.IsAccessDenied(awserr.New("AccessDeniedException", "", nil)))
+	assert.True(ackerr.IsValidation(awserr.New("InvalidParameterValue", "", nil)))
+	assert.True(ackerr.IsConflict(awserr.New("ResourceInUseException", "", nil)))
+	assert.True(ackerr.IsServiceUnavailable(awserr.New("InternalFailure", "", nil)))
+	assert.Equal(ackerr.ClassUnknown, ackerr.Classify(awserr.New("Unknown", "", nil)))
+
+	// Unknown codes are classified by the HTTP status code of the request
+	reqErr := func(code string, status int) error {
+		return awserr.NewRequestFailure(awserr.New(code, "", nil), status, "id")
+	}
+	assert.True(ackerr.IsThrottling(reqErr("Unknown", http.StatusTooManyRequests)))
+	assert.True(ackerr.IsNotFound(reqErr("Unknown", http.StatusNotFound)))
+	assert.True(ackerr.IsServiceUnavailable(reqErr("Unknown", http.StatusBadGateway)))
+	// ...but the code takes precedence over the status code
+	assert.True(ackerr.IsThrottling(reqErr("Throttling", http.StatusBadRequest)))
+}
+
+func TestClassifyServiceCodes(t *testing.T) {
+	assert := assert.New(t)
+
+	gk := &metav1.GroupKind{Group: "classify.services.k8s.aws", Kind: "Thing"}
+	otherGK := &metav1.GroupKind{Group: "other.services.k8s.aws", Kind: "Thing"}
+	ackerr.RegisterErrorCodes(gk.Group, ackerr.ClassNotFound, "ThingNotFoundFault")
+	ackerr.RegisterErrorCodes(gk.Group, ackerr.ClassConflict, "ResourceNotFoundException")
+
+	err := awserr.New("ThingNotFoundFault", "no such thing", nil)
+	assert.False(ackerr.IsNotFound(err))
+	assert.True(ackerr.IsNotFound(ackerr.Wrap(err, gk, "ReadOne")))
+	assert.False(ackerr.IsNotFound(ackerr.Wrap(err, otherGK, "ReadOne")))
+
+	// Service codes override the common codes
+	err = awserr.New("ResourceNotFoundException", "", nil)
+	assert.True(ackerr.IsConflict(ackerr.Wrap(err, gk, "Create")))
+	assert.True(ackerr.IsNotFound(ackerr.Wrap(err, otherGK, "Create")))
+}
+
+func TestWrap(t *testing.T) {
+	assert := assert.New(t)
+
+	gk := &metav1.GroupKind{Group: "ecr.services.k8s.aws", Kind: "Repository"}
+	assert.Nil(ackerr.Wrap(nil, gk, "Create"))
+
+	err := awserr.New("RepositoryAlreadyExistsException", "exists", nil)
+	wrapped := ackerr.Wrap(err, gk, "Create")
+	assert.Equal(
+		"Create Repository.ecr.services.k8s.aws: RepositoryAlreadyExistsException: exists",
+		wrapped.Error(),
+	)
+	awsErr, ok := ackerr.AWSError(wrapped)
+	assert.True(ok)
+	assert.Equal("RepositoryAlreadyExistsException", awsErr.Code())
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -34,11 +35,40 @@ var (
 	// information that the resource being checked for existence was
 	// previously-created out of band from ACK
 	AdoptedResourceNotFound = fmt.Errorf("adopted resource not found")
+	// ResourceNotStable is returned when the backend AWS service API
+	// resource is transitioning between states, e.g. "creating" or
+	// "modifying", and can't be modified until it reaches a stable state
+	ResourceNotStable = fmt.Errorf("resource is not in a stable state")
+	// ResourceBeingDeleted is returned when the backend AWS service API
+	// resource is still being deleted after a Delete operation
+	ResourceBeingDeleted = fmt.Errorf("resource is being deleted")
+	// ReconcilePaused is returned when reconciliation of a resource is paused
+	// by the services.k8s.aws/reconcile-paused annotation
+	ReconcilePaused = fmt.Errorf("reconciliation is paused")
+	// PolicyDenied is wrapped by the errors that policies return when they
+	// deny a mutating call to the backend AWS service API
+	PolicyDenied = fmt.Errorf("denied by policy")
+	// SecretKeyNotFound is returned when a Secret referred to by a CR doesn't
+	// contain the referenced key
+	SecretKeyNotFound = fmt.Errorf("secret key not found")
+	// ChildResourcesBeingDeleted is returned when the CRs of the child
+	// resources of a resource are still being deleted, before the backend AWS
+	// service API resource can be deleted
+	ChildResourcesBeingDeleted = fmt.Errorf("child resources are being deleted")
+	// DependenciesNotSynced is returned when the CRs that a CR depends on
+	// aren't synced yet, so the backend AWS service API resource for the CR
+	// can't be created or updated
+	DependenciesNotSynced = fmt.Errorf("dependencies are not synced")
+	// InvalidDependencies is wrapped by the errors returned when the
+	// services.k8s.aws/depends-on annotation of a CR is malformed or lists a
+	// CR of a kind that the service controller doesn't manage
+	InvalidDependencies = fmt.Errorf("invalid dependencies")
 )
 
-// AWSError returns the type conversion for the supplied error to an aws-sdk-go
-// Error interface
+// AWSError returns the type conversion for the supplied error, or the first
+// error it wraps that is one, to an aws-sdk-go Error interface
 func AWSError(err error) (awserr.Error, bool) {
-	awsErr, ok := err.(awserr.Error)
+	var awsErr awserr.Error
+	ok := errors.As(err, &awsErr)
 	return awsErr, ok
 }
@@ -237,3 +237,123 @@ func TestAPIGatewayV2_Route(t *testing.T) {
 		strings.TrimSpace(gotCode),
 	)
 }
+
+func TestAPIGatewayV2_Children(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "apigatewayv2")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// The generator.yaml file declares the resources contained in an API,
+	// which refer to it by the API ID returned by CreateApi
+	crd := getCRDByName("Api", crds)
+	require.NotNil(crd)
+	assert.Equal(
+		[]string{
+			"Stage", "Deployment", "RouteResponse", "Route",
+			"IntegrationResponse", "Integration", "Authorizer", "Model",
+		},
+		crd.ChildKinds(),
+	)
+	assert.Equal("Status.APIID", crd.ChildIdentifierPath())
+	assert.Equal("", crd.ParentKind())
+
+	crd = getCRDByName("Route", crds)
+	require.NotNil(crd)
+	assert.Nil(crd.ChildKinds())
+	assert.Equal("API", crd.ParentKind())
+	require.NotNil(crd.ParentIdentifierField())
+	assert.Equal("APIID", crd.ParentIdentifierField().Names.Camel)
+
+	// API mappings refer to an API but belong to a domain name
+	crd = getCRDByName("ApiMapping", crds)
+	require.NotNil(crd)
+	assert.Equal("", crd.ParentKind())
+	assert.Nil(crd.ParentIdentifierField())
+}
+
+func TestAPIGatewayV2_ARNTemplate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "apigatewayv2")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	// API Gateway ARNs have no account ID and are paths built from the IDs
+	// of the resource and of the API containing it, e.g.
+	// "arn:aws:apigateway:us-west-2::/apis/a1b2c3/routes/d4e5f6"
+	crd := getCRDByName("Api", crds)
+	require.NotNil(crd)
+	assert.Equal(
+		"arn:{partition}:apigateway:{region}::/apis/{ApiId}",
+		crd.ARNTemplate(),
+	)
+	assert.Equal(
+		[]model.ARNTemplateField{{Name: "ApiId", Path: "Status.APIID"}},
+		crd.ARNTemplateFields(),
+	)
+	assert.Nil(crd.ARNTemplateNameField())
+	// The API ID isn't the API's name, so ARNFromName can't build the ARN
+	assert.Nil(crd.ARNTemplateNameOnlyField())
+
+	// The domain name is both the name of the DomainName resource and the
+	// only field placeholder in its ARN template
+	crd = getCRDByName("DomainName", crds)
+	require.NotNil(crd)
+	require.NotNil(crd.ARNTemplateNameOnlyField())
+	assert.Equal("DomainName", crd.ARNTemplateNameOnlyField().Name)
+
+	crd = getCRDByName("RouteResponse", crds)
+	require.NotNil(crd)
+	assert.Equal(
+		[]model.ARNTemplateField{
+			{Name: "ApiId", Path: "Spec.APIID"},
+			{Name: "RouteId", Path: "Spec.RouteID"},
+			{Name: "RouteResponseId", Path: "Status.RouteResponseID"},
+		},
+		crd.ARNTemplateFields(),
+	)
+
+	crd = getCRDByName("Stage", crds)
+	require.NotNil(crd)
+	assert.Equal(
+		[]model.ARNTemplateField{
+			{Name: "ApiId", Path: "Spec.APIID"},
+			{Name: "StageName", Path: "Spec.StageName"},
+		},
+		crd.ARNTemplateFields(),
+	)
+}
+
+func TestAPIGatewayV2_DomainName_State(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "apigatewayv2")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("DomainName", crds)
+	require.NotNil(crd)
+
+	// The state of a DomainName is nested in the single element of its
+	// DomainNameConfigurations list
+	stateField := crd.StateField()
+	require.NotNil(stateField)
+	assert.Equal("DomainNameConfigurations", stateField.Names.Camel)
+
+	expState := `	if len(r.ko.Spec.DomainNameConfigurations) == 0 ||
+		r.ko.Spec.DomainNameConfigurations[0] == nil ||
+		r.ko.Spec.DomainNameConfigurations[0].DomainNameStatus == nil {
+		return ""
+	}
+	return *r.ko.Spec.DomainNameConfigurations[0].DomainNameStatus
+`
+	assert.Equal(expState, crd.GoCodeState("r.ko", 1))
+}
@@ -15,6 +15,7 @@ package config
 
 import (
 	"io/ioutil"
+	"sort"
 
 	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
 	"github.com/ghodss/yaml"
@@ -32,6 +33,22 @@ type Config struct {
 	Ignore IgnoreSpec `json:"ignore"`
 	// Contains generator instructions for individual API operations.
 	Operations map[string]OperationConfig `json:"operations"`
+	// Errors contains the error codes returned by the AWS service API for
+	// each class of errors that the runtime's error classification helpers
+	// recognize, in addition to the error codes common to all AWS APIs
+	Errors *ErrorsConfig `json:"errors,omitempty"`
+}
+
+// ErrorsConfig contains the error codes, for each class of errors, that the
+// generated service controller registers with the runtime's error
+// classification helpers (`ackerr.IsThrottling()` etc.)
+type ErrorsConfig struct {
+	Throttling         []string `json:"throttling,omitempty"`
+	AccessDenied       []string `json:"access_denied,omitempty"`
+	NotFound           []string `json:"not_found,omitempty"`
+	Validation         []string `json:"validation,omitempty"`
+	Conflict           []string `json:"conflict,omitempty"`
+	ServiceUnavailable []string `json:"service_unavailable,omitempty"`
 }
 
 // OperationConfig represents instructions to the ACK code generator to
@@ -90,6 +107,37 @@ type ResourceConfig struct {
 	// filter the results of these List operations from within the generated
 	// code in sdk.go's sdkFind().
 	ListOperation *ListOperationConfig `json:"list_operation,omitempty"`
+	// DefaultValues is a map, keyed by the name of a field in the CRD's Spec,
+	// of the values that the defaulting admission webhook sets on the field
+	// when the Kubernetes user has not supplied a value for it.
+	DefaultValues map[string]string `json:"default_values,omitempty"`
+	// Tags contains instructions for the code generator to generate Go code
+	// that keeps the tags of the resource in sync with the tags in the CR's
+	// Spec using the service API's tagging operations.
+	Tags *TagsConfig `json:"tags,omitempty"`
+	// State contains instructions for the code generator to generate Go
+	// code that determines whether the backend AWS service API resource is
+	// in a stable state, from the value of a field in the CR's Status.
+	State *StateConfig `json:"state,omitempty"`
+	// ARNTemplate is the template of the resource's Amazon Resource Name
+	// (ARN) used by the generated resource manager's ARNFromName method, e.g.
+	// "arn:{partition}:ecr:{region}:{account}:repository/{name}". The
+	// {partition}, {region}, {account} and {name} placeholders are replaced
+	// by the partition of the region, the region, the AWS account ID and the
+	// name of the resource. Any other placeholder names a string field in the
+	// CR's Spec or Status by its SDK member name, e.g. {ApiId}, and is
+	// replaced by that field's value, for resources whose ARNs contain more
+	// than the name, e.g.
+	// "arn:{partition}:apigateway:{region}::/apis/{ApiId}". Defaults to
+	// "arn:{partition}:<service>:{region}:{account}:{name}".
+	ARNTemplate *string `json:"arn_template,omitempty"`
+	// Fields is a map, keyed by the name of a field in the CRD's Spec, of
+	// FieldConfig instructions for fields that need special handling
+	Fields map[string]FieldConfig `json:"fields,omitempty"`
+	// Children contains instructions for the code generator to handle
+	// resources whose backend AWS service API resources are contained in the
+	// resource's, and are implicitly deleted along with it
+	Children *ChildrenConfig `json:"children,omitempty"`
 }
 
 // UnpackAttributesMapConfig informs the code generator that the API follows a
@@ -165,6 +213,12 @@ type FieldConfig struct {
 	// that owns the resource. This is a special field that we direct to
 	// storage in the common `Status.ACKResourceMetadata.OwnerAccountID` field.
 	ContainsOwnerAccountID bool `json:"contains_owner_account_id"`
+	// IsSecret indicates the field contains a sensitive value, e.g. a
+	// password. Instead of the value itself, the CR's Spec field contains a
+	// reference to a key in a Kubernetes Secret holding the value. Marking a
+	// field of a released CRD changes the field's type and so breaks existing
+	// CRs that set it.
+	IsSecret bool `json:"is_secret"`
 }
 
 // ExceptionsConfig contains instructions to the code generator about how to
@@ -203,6 +257,83 @@ type ListOperationConfig struct {
 	// MatchFields lists the names of fields in the Shape of the
 	// list element in the List Operation's Output shape.
 	MatchFields []string `json:"match_fields"`
+	// FilterCustomMethodName is the name of a custom method on the
+	// `resourceManager` struct that returns whether a resource returned by
+	// the List operation is in the resource manager's AWS account and
+	// region, for List operations that return resources in every region,
+	// e.g. S3's ListBuckets. The generated code that lists all the
+	// resources of the kind skips the resources the method returns false
+	// for.
+	FilterCustomMethodName string `json:"filter_custom_method_name,omitempty"`
+}
+
+// TagsConfig contains instructions for the code generator to handle the tags
+// of a resource. Many AWS service APIs don't return a resource's tags in the
+// resource's Read operation output and can't modify a resource's tags in the
+// resource's Update operation. Instead, separate operations are used to list,
+// add and remove the tags for any resource in the service, identified by the
+// resource's ARN (or, for some APIs like S3, by the resource's name).
+//
+// The generator inspects the Input and Output shapes of these operations to
+// determine the members containing the resource's ARN or name, the tags and
+// the keys of the tags to remove. If the resource's Create operation doesn't
+// accept tags, the generator adds a Spec field for them.
+type TagsConfig struct {
+	// Field is the name of the Spec field containing the resource's tags.
+	// Defaults to "Tags".
+	Field *string `json:"field,omitempty"`
+	// ListOperation is the name of the operation that returns the tags for a
+	// resource, e.g. "ListTagsForResource"
+	ListOperation string `json:"list_operation"`
+	// TagOperation is the name of the operation that adds tags to or
+	// overwrites existing tags on a resource, e.g. "TagResource"
+	TagOperation string `json:"tag_operation"`
+	// UntagOperation is the name of the operation that removes tags from a
+	// resource, e.g. "UntagResource". If the operation doesn't accept the
+	// keys of the tags to remove, e.g. S3's "DeleteBucketTagging", it is
+	// expected to remove all of the resource's tags and TagOperation is
+	// expected to replace the whole set of tags.
+	UntagOperation string `json:"untag_operation"`
+	// NoTagsErrorCode is the error code ListOperation returns when the
+	// resource has no tags, for APIs that return an error instead of an
+	// empty set of tags, e.g. "NoSuchTagSet"
+	NoTagsErrorCode *string `json:"no_tags_error_code,omitempty"`
+}
+
+// StateConfig contains instructions for the code generator to handle
+// resources that go through transitional states, e.g. "creating" or
+// "modifying", before they can be used and modified. The ACK runtime doesn't
+// consider these resources synced, and doesn't modify them, until they reach
+// a stable state.
+type StateConfig struct {
+	// Field is the name of the Status field containing the state of the
+	// resource, e.g. "Status", or the dot-separated path to the state when
+	// it's nested in a structure or list field of the Status or Spec, e.g.
+	// "DomainNameConfigurations.DomainNameStatus". Lists along the path are
+	// expected to have a single element, whose state is the resource's.
+	Field string `json:"field"`
+	// StableValues are the values of the state field that indicate the
+	// resource is in a stable state, e.g. "available"
+	StableValues []string `json:"stable_values"`
+	// FailedValues are the values of the state field that indicate the
+	// resource is in a terminal state from which it will not recover, e.g.
+	// "create-failed"
+	FailedValues []string `json:"failed_values,omitempty"`
+}
+
+// ChildrenConfig contains instructions for the code generator to handle
+// child resources, e.g. the Routes and Stages of an API Gateway API. The ACK
+// runtime makes the parent's CR the owner of its children's CRs and, when the
+// parent's CR is deleted, deletes the children's CRs before deleting the
+// parent's backend AWS service API resource.
+type ChildrenConfig struct {
+	// Field is the name of the field containing the identifier by which the
+	// child resources refer to the resource, e.g. "ApiId". It's a Spec or
+	// Status field of the resource and a Spec field of the child resources.
+	Field string `json:"field"`
+	// Resources are the names of the child resources, in the order they're
+	// deleted, e.g. "Stage"
+	Resources []string `json:"resources"`
 }
 
 // IsIgnoredOperation returns true if Operation Name is configured to be ignored
@@ -327,6 +458,126 @@ func (c *Config) ListOpMatchFieldNames(
 	return rConfig.ListOperation.MatchFields
 }
 
+// ResourceDefaultValues returns a map, keyed by Spec field name, of default
+// values for the fields of the supplied resource
+func (c *Config) ResourceDefaultValues(
+	resName string,
+) map[string]string {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found {
+		return nil
+	}
+	return rConfig.DefaultValues
+}
+
+// ResourceTagsConfig returns the TagsConfig for the supplied resource, or nil
+// if the resource's tags aren't kept in sync using tagging operations
+func (c *Config) ResourceTagsConfig(
+	resName string,
+) *TagsConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found {
+		return nil
+	}
+	return rConfig.Tags
+}
+
+// ResourceStateConfig returns the StateConfig for the supplied resource, or
+// nil if the resource doesn't go through transitional states
+func (c *Config) ResourceStateConfig(
+	resName string,
+) *StateConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found {
+		return nil
+	}
+	return rConfig.State
+}
+
+// ResourceARNTemplate returns the ARN template configured for the supplied
+// resource name, or the empty string if none is configured
+func (c *Config) ResourceARNTemplate(
+	resName string,
+) string {
+	if c == nil {
+		return ""
+	}
+	rConfig, found := c.Resources[resName]
+	if !found || rConfig.ARNTemplate == nil {
+		return ""
+	}
+	return *rConfig.ARNTemplate
+}
+
+// ResourceChildrenConfig returns the ChildrenConfig for the supplied
+// resource, or nil if the resource has no child resources
+func (c *Config) ResourceChildrenConfig(
+	resName string,
+) *ChildrenConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found {
+		return nil
+	}
+	return rConfig.Children
+}
+
+// ResourceParent returns the name of the parent resource of the supplied
+// resource and the parent's ChildrenConfig, or the empty string and nil if
+// the resource isn't a child resource
+func (c *Config) ResourceParent(
+	resName string,
+) (string, *ChildrenConfig) {
+	if c == nil {
+		return "", nil
+	}
+	parentNames := make([]string, 0, len(c.Resources))
+	for parentName := range c.Resources {
+		parentNames = append(parentNames, parentName)
+	}
+	// A resource has a single parent, but we still want to return the same
+	// one every time if it's misconfigured
+	sort.Strings(parentNames)
+	for _, parentName := range parentNames {
+		children := c.Resources[parentName].Children
+		if children != nil && util.InStrings(resName, children.Resources) {
+			return parentName, children
+		}
+	}
+	return "", nil
+}
+
+// ResourceFieldConfig returns the FieldConfig for the supplied resource and
+// field name, or nil if there are no instructions for the field
+func (c *Config) ResourceFieldConfig(
+	resName string,
+	fieldName string,
+) *FieldConfig {
+	if c == nil {
+		return nil
+	}
+	rConfig, found := c.Resources[resName]
+	if !found {
+		return nil
+	}
+	fConfig, found := rConfig.Fields[fieldName]
+	if !found {
+		return nil
+	}
+	return &fConfig
+}
+
 // New returns a new Config object given a supplied
 // path to a config file
 func New(
@@ -342,3 +593,26 @@ func New(
 	}
 	return &gc, nil
 }
+
+// ErrorCodes returns the error codes configured for the AWS service API,
+// keyed by the name of the `ackerr.Class` constant for the class of errors
+// the codes belong to, e.g. "ClassThrottling"
+func (c *Config) ErrorCodes() map[string][]string {
+	res := map[string][]string{}
+	if c == nil || c.Errors == nil {
+		return res
+	}
+	for class, codes := range map[string][]string{
+		"ClassThrottling":         c.Errors.Throttling,
+		"ClassAccessDenied":       c.Errors.AccessDenied,
+		"ClassNotFound":           c.Errors.NotFound,
+		"ClassValidation":         c.Errors.Validation,
+		"ClassConflict":           c.Errors.Conflict,
+		"ClassServiceUnavailable": c.Errors.ServiceUnavailable,
+	} {
+		if len(codes) > 0 {
+			res[class] = codes
+		}
+	}
+	return res
+}
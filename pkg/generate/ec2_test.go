@@ -49,11 +49,12 @@ func TestEC2_LaunchTemplate(t *testing.T) {
 	statusFields := crd.StatusFields
 
 	expSpecFieldCamel := []string{
-		// TODO(jaypipes): DryRun and ClientToken are examples of two fields in
-		// the resource input shape that need to be stripped out of the CRD. We
-		// need to instruct the code generator that these types of fields are
-		// not germane to the resource itself...
-		"ClientToken",
+		// ClientToken is an idempotency token, which the service controller
+		// sets from the CR's UID and is not included in the CRD.
+		// TODO(jaypipes): DryRun is an example of a field in the resource
+		// input shape that needs to be stripped out of the CRD. We need to
+		// instruct the code generator that these types of fields are not
+		// germane to the resource itself...
 		"DryRun",
 		"LaunchTemplateData",
 		"LaunchTemplateName",
@@ -87,9 +88,7 @@ func TestEC2_LaunchTemplate(t *testing.T) {
 	// Go code to set the Input Shape member from the Spec field but not set a
 	// Status field from the Create Output Shape member
 	expCreateInput := `
-	if r.ko.Spec.ClientToken != nil {
-		res.SetClientToken(*r.ko.Spec.ClientToken)
-	}
+	res.SetClientToken(string(r.ko.GetUID()))
 	if r.ko.Spec.DryRun != nil {
 		res.SetDryRun(*r.ko.Spec.DryRun)
 	}
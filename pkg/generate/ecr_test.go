@@ -243,3 +243,122 @@ func TestECRRepository(t *testing.T) {
 `
 	assert.Equal(expReadManyOutput, crd.GoCodeSetOutput(model.OpTypeList, "resp", "ko", 1))
 }
+
+func TestECRRepository_Validation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// RepositoryName is a required member of the CreateRepositoryRequest
+	// shape and the RepositoryName shape has min, max and pattern
+	// constraints. ImageTagMutability is an enum. The other Spec fields are
+	// structs or lists without constraints and aren't validated.
+	expValidateSpec := `
+	if ko.Spec.ImageTagMutability != nil {
+		switch *ko.Spec.ImageTagMutability {
+		case string(svcapitypes.ImageTagMutability_MUTABLE),
+			string(svcapitypes.ImageTagMutability_IMMUTABLE):
+		default:
+			errs = append(errs, field.NotSupported(field.NewPath("spec", "imageTagMutability"), *ko.Spec.ImageTagMutability, []string{"MUTABLE", "IMMUTABLE"}))
+		}
+	}
+	if ko.Spec.RepositoryName == nil {
+		errs = append(errs, field.Required(field.NewPath("spec", "repositoryName"), ""))
+	}
+	if ko.Spec.RepositoryName != nil {
+		if len(*ko.Spec.RepositoryName) < 2 {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "repositoryName"), *ko.Spec.RepositoryName, "must be at least 2 characters long"))
+		}
+		if len(*ko.Spec.RepositoryName) > 256 {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "repositoryName"), *ko.Spec.RepositoryName, "must be no more than 256 characters long"))
+		}
+		if !specPatternRepositoryName.MatchString(*ko.Spec.RepositoryName) {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "repositoryName"), *ko.Spec.RepositoryName, "must match the pattern (?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*"))
+		}
+	}
+`
+	assert.Equal(expValidateSpec, "\n"+crd.GoCodeValidateSpec("ko", "errs", 1))
+
+	// The patterns are compiled once, in package-level variables
+	expPatterns := `	specPatternRepositoryName = regexp.MustCompile("^(?:(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*)$")
+`
+	assert.Equal(expPatterns, crd.GoCodeValidationPatterns(1))
+
+	// No default values are configured for the ECR Repository
+	assert.Equal("", crd.GoCodeDefaultSpec("ko", 1))
+}
+
+func TestECRRepository_Tags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "ecr")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Repository", crds)
+	require.NotNil(crd)
+
+	// The Repository's tags are a list of Tag structs in the Spec
+	tagField := crd.TagField()
+	require.NotNil(tagField)
+	assert.Equal("Tags", tagField.Names.Camel)
+	assert.False(crd.TagFieldIsMap())
+	assert.True(crd.CreateSetsTags())
+
+	// The generator.yaml file names the ECR tagging operations and the
+	// generator finds the members containing the ARN and tags
+	tagOps := crd.TagOps()
+	require.NotNil(tagOps)
+
+	assert.Equal("ListTagsForResource", tagOps.List.Operation.Name)
+	assert.Equal("ResourceArn", tagOps.List.IdentifierMember)
+	assert.False(tagOps.List.IdentifierIsName)
+	assert.Equal("Tags", tagOps.List.TagsMember)
+	assert.False(tagOps.List.TagsIsMap)
+	assert.Equal("Tag", tagOps.List.TagShapeName)
+
+	assert.Equal("TagResource", tagOps.Tag.Operation.Name)
+	assert.Equal("ResourceArn", tagOps.Tag.IdentifierMember)
+	assert.Equal("Tags", tagOps.Tag.TagsMember)
+
+	assert.Equal("UntagResource", tagOps.Untag.Operation.Name)
+	assert.Equal("ResourceArn", tagOps.Untag.IdentifierMember)
+	assert.Equal("TagKeys", tagOps.Untag.TagsMember)
+	assert.False(tagOps.Untag.RemovesAllTags())
+}
+
+func TestECR_ErrorCodes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "ecr")
+
+	// The codes from the generator.yaml file are registered along with the
+	// code of the 404 Not Found error of the Repository resource, so that
+	// generated code checking for ackerr.IsNotFound recognizes both
+	errorCodes, err := g.ErrorCodes()
+	require.Nil(err)
+	assert.Equal(
+		map[string][]string{
+			"ClassNotFound": {
+				"RepositoryPolicyNotFoundException",
+				"LifecyclePolicyNotFoundException",
+				"RepositoryNotFoundException",
+			},
+			"ClassConflict": {
+				"RepositoryAlreadyExistsException",
+				"RepositoryNotEmptyException",
+			},
+		},
+		errorCodes,
+	)
+}
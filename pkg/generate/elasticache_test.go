@@ -672,7 +672,13 @@ func TestElasticache_Override_Values(t *testing.T) {
 	expected := `
 	res.SetApplyImmediately(true)
 	if r.ko.Spec.AuthToken != nil {
-		res.SetAuthToken(*r.ko.Spec.AuthToken)
+		tmpSecret, err := rm.rr.SecretValueFromReference(ctx, r.ko.Spec.AuthToken)
+		if err != nil {
+			return nil, err
+		}
+		if tmpSecret != "" {
+			res.SetAuthToken(tmpSecret)
+		}
 	}
 	if r.ko.Spec.AutoMinorVersionUpgrade != nil {
 		res.SetAutoMinorVersionUpgrade(*r.ko.Spec.AutoMinorVersionUpgrade)
@@ -738,3 +744,62 @@ func TestElasticache_Override_Values(t *testing.T) {
 	assert := assert.New(t)
 	assert.Equal(expected, crd.GoCodeSetInput(model.OpTypeUpdate, "r.ko", "res", 1))
 }
+
+func TestElasticache_ReplicationGroup_State(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "elasticache")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("ReplicationGroup", crds)
+	require.NotNil(crd)
+
+	// The generator.yaml file names the Status field containing the
+	// ReplicationGroup's state and its stable and failed values
+	stateField := crd.StateField()
+	require.NotNil(stateField)
+	assert.Equal("Status", stateField.Names.Camel)
+	assert.Equal([]string{"available"}, crd.StateConfig().StableValues)
+	assert.Equal([]string{"create-failed"}, crd.StateConfig().FailedValues)
+
+	expState := `	if r.ko.Status.Status == nil {
+		return ""
+	}
+	return *r.ko.Status.Status
+`
+	assert.Equal(expState, crd.GoCodeState("r.ko", 1))
+
+	// CacheSubnetGroups don't go through transitional states
+	crd = getCRDByName("CacheSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.Nil(crd.StateField())
+}
+
+func TestElasticache_ReplicationGroup_SecretFields(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "elasticache")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("ReplicationGroup", crds)
+	require.NotNil(crd)
+
+	// The generator.yaml file marks the AuthToken field as a secret, so the
+	// Spec field refers to a key in a Kubernetes Secret
+	require.True(crd.HasSecretFields())
+	secretFields := crd.SecretFields()
+	require.Len(secretFields, 1)
+	assert.Equal("AuthToken", secretFields[0].Names.Camel)
+	assert.Equal("*ackv1alpha1.SecretKeyReference", secretFields[0].GoType)
+
+	// CacheSubnetGroups don't have secret fields
+	crd = getCRDByName("CacheSubnetGroup", crds)
+	require.NotNil(crd)
+	assert.False(crd.HasSecretFields())
+}
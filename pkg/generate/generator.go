@@ -90,6 +90,11 @@ func (g *Generator) GetCRDs() ([]*ackmodel.CRD, error) {
 			if g.cfg.IsIgnoredShape(memberShapeRef.Shape.ShapeName) {
 				continue
 			}
+			if ackmodel.IsIdempotencyToken(memberName, memberShapeRef) {
+				// The service controller sets idempotency tokens itself
+				// when it calls the Create operation
+				continue
+			}
 			renamedName, _ := crd.InputFieldRename(
 				createOp.Name, memberName,
 			)
@@ -100,6 +105,7 @@ func (g *Generator) GetCRDs() ([]*ackmodel.CRD, error) {
 			}
 			crd.AddSpecField(memberNames, memberShapeRef)
 		}
+		crd.AddTagField()
 
 		// Now process the fields that will go into the Status struct. We want
 		// fields that are in the Create operation's Output Shape but that are
@@ -122,6 +128,9 @@ func (g *Generator) GetCRDs() ([]*ackmodel.CRD, error) {
 			if memberShapeRef.Shape == nil {
 				return nil, ackmodel.ErrNilShapePointer
 			}
+			if ackmodel.IsIdempotencyToken(memberName, memberShapeRef) {
+				continue
+			}
 			memberNames := names.New(memberName)
 			if _, found := crd.SpecFields[memberName]; found {
 				// We don't put fields that are already in the Spec struct into
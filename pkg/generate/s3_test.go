@@ -82,6 +82,10 @@ func TestS3_Bucket(t *testing.T) {
 		// ListBuckets API call...
 		"Name",
 		"ObjectLockEnabledForBucket",
+		// CreateBucket doesn't take tags. The generator adds the Tags field
+		// because the generator.yaml configures the bucket tagging
+		// operations
+		"Tags",
 	}
 	assert.Equal(expSpecFieldCamel, attrCamelNames(specFields))
 
@@ -161,4 +165,51 @@ func TestS3_Bucket(t *testing.T) {
 	}
 `
 	assert.Equal(expReadManyOutput, crd.GoCodeSetOutput(model.OpTypeList, "resp", "ko", 1))
+
+	// ListBuckets returns the buckets in every region, so the generator.yaml
+	// names a custom method that filters the buckets listed by sdkFindAll
+	assert.True(crd.CanReadAll())
+	assert.Equal("CustomBucketInRegion", crd.ReadManyFilterCustomMethodName())
+}
+
+func TestS3Bucket_Tags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "s3")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("Bucket", crds)
+	require.NotNil(crd)
+
+	// The Tags field has the shape of the TagSet returned by GetBucketTagging
+	tagField := crd.TagField()
+	require.NotNil(tagField)
+	assert.Equal("Tags", tagField.Names.Camel)
+	assert.Equal("[]*Tag", tagField.GoType)
+	assert.False(crd.CreateSetsTags())
+
+	tagOps := crd.TagOps()
+	require.NotNil(tagOps)
+	assert.Equal("NoSuchTagSet", tagOps.NoTagsErrorCode)
+
+	// The S3 tagging operations identify the bucket by name
+	assert.Equal("GetBucketTagging", tagOps.List.Operation.Name)
+	assert.Equal("Bucket", tagOps.List.IdentifierMember)
+	assert.True(tagOps.List.IdentifierIsName)
+	assert.Equal("TagSet", tagOps.List.TagsMember)
+
+	// PutBucketTagging takes the tags in its Tagging member
+	assert.Equal("PutBucketTagging", tagOps.Tag.Operation.Name)
+	assert.Equal("Bucket", tagOps.Tag.IdentifierMember)
+	assert.Equal("TagSet", tagOps.Tag.TagsMember)
+	assert.Equal("Tagging", tagOps.Tag.TagsContainerMember)
+	assert.Equal("Tagging", tagOps.Tag.TagsContainerShapeName)
+	assert.Equal("Tag", tagOps.Tag.TagShapeName)
+
+	// DeleteBucketTagging removes all of the bucket's tags
+	assert.Equal("DeleteBucketTagging", tagOps.Untag.Operation.Name)
+	assert.True(tagOps.Untag.RemovesAllTags())
 }
@@ -232,3 +232,47 @@ func TestSNS_Topic(t *testing.T) {
 		),
 	)
 }
+
+func TestSNS_PlatformApplication_ARNTemplate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	g := testutil.NewGeneratorForService(t, "sns")
+
+	crds, err := g.GetCRDs()
+	require.Nil(err)
+
+	crd := getCRDByName("PlatformApplication", crds)
+	require.NotNil(crd)
+
+	// Platform application ARNs contain the platform as well as the name,
+	// e.g. "arn:aws:sns:us-west-2:123456789012:app/GCM/my-app"
+	assert.Equal(
+		"arn:{partition}:sns:{region}:{account}:app/{Platform}/{name}",
+		crd.ARNTemplate(),
+	)
+	assert.Equal(
+		[]model.ARNTemplateField{{Name: "Platform", Path: "Spec.Platform"}},
+		crd.ARNTemplateFields(),
+	)
+	require.NotNil(crd.ARNTemplateNameField())
+	assert.Equal("Name", crd.ARNTemplateNameField().Names.Camel)
+	assert.Nil(crd.ARNTemplateNameOnlyField())
+
+	// So the ARN of a platform application that hasn't been created yet is
+	// built from the whole CR rather than from its name alone
+	expGetAttrsInput := `
+	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
+		res.SetPlatformApplicationArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
+	} else {
+		res.SetPlatformApplicationArn(rm.arnFromCR(r.ko))
+	}
+`
+	assert.Equal(expGetAttrsInput, crd.GoCodeGetAttributesSetInput("r.ko", "res", 1))
+
+	// The topic's ARN is the default one for the service
+	crd = getCRDByName("Topic", crds)
+	require.NotNil(crd)
+	assert.Equal("arn:{partition}:sns:{region}:{account}:{name}", crd.ARNTemplate())
+	assert.Empty(crd.ARNTemplateFields())
+}
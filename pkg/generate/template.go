@@ -18,6 +18,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"strings"
 	ttpl "text/template"
 
@@ -38,14 +39,22 @@ var (
 		"pkg/crd_manager_factory",
 		"pkg/crd_resource",
 		"pkg/crd_sdk",
+		"pkg/crd_webhook",
 		"pkg/resource_registry",
+		"pkg/service_controller",
 	}
 	yamlTemplatePaths = []string{
 		"config/controller/deployment",
 		"config/controller/kustomization",
 		"config/default/kustomization",
+		"config/default/webhook_patch",
 		"config/rbac/cluster-role-binding",
 		"config/rbac/kustomization",
+		"config/webhook/certificate",
+		"config/webhook/kustomization",
+		"config/webhook/kustomizeconfig",
+		"config/webhook/manifests",
+		"config/webhook/service",
 	}
 	goTemplateFuncMap = ttpl.FuncMap{
 		"ToLower": strings.ToLower,
@@ -61,6 +70,9 @@ var (
 		"GoCodeSetReadManyOutput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
 			return r.GoCodeSetOutput(ackmodel.OpTypeList, sourceVarName, targetVarName, indentLevel)
 		},
+		"GoCodeSetReadManyElemOutput": func(r *ackmodel.CRD, targetVarName string, indentLevel int) string {
+			return r.GoCodeSetReadManyElemOutput(targetVarName, indentLevel)
+		},
 		"GoCodeSetReadManyInput": func(r *ackmodel.CRD, sourceVarName string, targetVarName string, indentLevel int) string {
 			return r.GoCodeSetInput(ackmodel.OpTypeList, sourceVarName, targetVarName, indentLevel)
 		},
@@ -100,6 +112,29 @@ var (
 		"GoCodeRequiredFieldsMissingFromSetAttributesInput": func(r *ackmodel.CRD, koVarName string, indentLevel int) string {
 			return r.GoCodeRequiredFieldsMissingFromShape(ackmodel.OpTypeSetAttributes, koVarName, indentLevel)
 		},
+		"GoCodeValidateSpec": func(r *ackmodel.CRD, koVarName string, errsVarName string, indentLevel int) string {
+			return r.GoCodeValidateSpec(koVarName, errsVarName, indentLevel)
+		},
+		"GoCodeValidationPatterns": func(r *ackmodel.CRD, indentLevel int) string {
+			return r.GoCodeValidationPatterns(indentLevel)
+		},
+		"GoCodeDefaultSpec": func(r *ackmodel.CRD, koVarName string, indentLevel int) string {
+			return r.GoCodeDefaultSpec(koVarName, indentLevel)
+		},
+		"GoCodeState": func(r *ackmodel.CRD, koVarName string, indentLevel int) string {
+			return r.GoCodeState(koVarName, indentLevel)
+		},
+	}
+	yamlTemplateFuncMap = ttpl.FuncMap{
+		"ToLower": strings.ToLower,
+		// WebhookPath returns the path that the service controller serves
+		// the admission webhook with the supplied prefix for the supplied
+		// CRD on. This must be kept in sync with the `webhookPath` function
+		// in pkg/runtime.
+		"WebhookPath": func(prefix string, apiGroup string, r *ackmodel.CRD) string {
+			return "/" + prefix + "-" + strings.Replace(apiGroup, ".", "-", -1) +
+				"-" + strings.ToLower(r.Kind)
+		},
 	}
 )
 
@@ -160,6 +195,22 @@ type templateCmdVars struct {
 	SnakeCasedCRDNames []string
 }
 
+// templateRegistryVars contains template variables for the template that
+// outputs the resource registry for the service controller
+type templateRegistryVars struct {
+	templateMetaVars
+	// ErrorCodes contains the error codes from the generator config, keyed
+	// by the name of the `ackerr.Class` constant for their class of errors
+	ErrorCodes map[string][]string
+}
+
+// templateConfigVars contains template variables for the templates that
+// output Kubernetes YAML manifests in the /services/$SERVICE/config directory
+type templateConfigVars struct {
+	templateMetaVars
+	CRDs []*ackmodel.CRD
+}
+
 // templateMetaVars returns a templateMetaVars struct populated with metadata
 // about the AWS service API
 func (g *Generator) templateMetaVars() templateMetaVars {
@@ -229,6 +280,19 @@ func (g *Generator) templateCmdVars() (*templateCmdVars, error) {
 	}, nil
 }
 
+// templateConfigVars returns a templateConfigVars struct populated with
+// information for files in a service controller's config/ directory
+func (g *Generator) templateConfigVars() (*templateConfigVars, error) {
+	crds, err := g.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	return &templateConfigVars{
+		g.templateMetaVars(),
+		crds,
+	}, nil
+}
+
 // initTemplates initializes the templates for generating Kubernetes API
 // type files and the service controller Go code files
 func (g *Generator) initTemplates() error {
@@ -267,6 +331,7 @@ func (g *Generator) initTemplates() error {
 			return err
 		}
 		t := ttpl.New(path)
+		t = t.Funcs(yamlTemplateFuncMap)
 		t, err = t.Parse(string(tplContents))
 		if err != nil {
 			return err
@@ -340,6 +405,64 @@ func (g *Generator) GenerateCmdControllerMainFile() (*bytes.Buffer, error) {
 	return &b, nil
 }
 
+// GenerateServiceControllerFile returns a byte buffer containing the output of
+// an executed template for the package that constructs the service
+// controller, which is shared by the service's own controller binary and
+// multi-service controller binaries
+func (g *Generator) GenerateServiceControllerFile() (*bytes.Buffer, error) {
+	if err := g.initTemplates(); err != nil {
+		return nil, err
+	}
+	t := g.templates["pkg/service_controller"]
+	vars, err := g.templateCmdVars()
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, vars); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// templateCombinedVars contains template variables for the template that
+// outputs the main.go file of a binary hosting several service controllers
+type templateCombinedVars struct {
+	// Services contains the sorted aliases of the services, which are also
+	// the names of the services' directories, e.g. "ecr"
+	Services []string
+}
+
+// GenerateCombinedControllerMainFile returns a byte buffer containing the
+// output of an executed template for the main.go file of a single binary
+// hosting the service controllers of the supplied services
+func GenerateCombinedControllerMainFile(
+	templateBasePath string,
+	services []string,
+) (*bytes.Buffer, error) {
+	path := "cmd/combined/main"
+	tplContents, err := ioutil.ReadFile(
+		filepath.Join(templateBasePath, path+".go.tpl"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ttpl.New(path).Parse(string(tplContents))
+	if err != nil {
+		return nil, err
+	}
+	if t, err = IncludeTemplate(t, templateBasePath, "boilerplate"); err != nil {
+		return nil, err
+	}
+	sorted := append([]string{}, services...)
+	sort.Strings(sorted)
+	var b bytes.Buffer
+	if err := t.Execute(&b, &templateCombinedVars{sorted}); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
 // GenerateResourceRegistryFile returns a byte buffer containing the output of
 // an executed template containing the resource registry for the service
 // controller
@@ -347,8 +470,15 @@ func (g *Generator) GenerateResourceRegistryFile() (*bytes.Buffer, error) {
 	if err := g.initTemplates(); err != nil {
 		return nil, err
 	}
+	errorCodes, err := g.ErrorCodes()
+	if err != nil {
+		return nil, err
+	}
 	t := g.templates["pkg/resource_registry"]
-	vars := g.templateMetaVars()
+	vars := &templateRegistryVars{
+		g.templateMetaVars(),
+		errorCodes,
+	}
 	var b bytes.Buffer
 	if err := t.Execute(&b, vars); err != nil {
 		return nil, err
@@ -356,6 +486,38 @@ func (g *Generator) GenerateResourceRegistryFile() (*bytes.Buffer, error) {
 	return &b, nil
 }
 
+// ErrorCodes returns the error codes registered by the service controller,
+// keyed by the name of the `ackerr.Class` constant for their class of errors.
+// These are the codes from the generator config, along with the code of the
+// 404 Not Found error of each resource, unless the generator config puts
+// that code in another class.
+func (g *Generator) ErrorCodes() (map[string][]string, error) {
+	crds, err := g.GetCRDs()
+	if err != nil {
+		return nil, err
+	}
+	res := g.cfg.ErrorCodes()
+	configured := map[string]bool{}
+	for _, codes := range res {
+		for _, code := range codes {
+			configured[code] = true
+		}
+	}
+	notFound := append([]string{}, res["ClassNotFound"]...)
+	for _, crd := range crds {
+		code := crd.ExceptionCode(404)
+		if code == "UNKNOWN" || configured[code] {
+			continue
+		}
+		configured[code] = true
+		notFound = append(notFound, code)
+	}
+	if len(notFound) > 0 {
+		res["ClassNotFound"] = notFound
+	}
+	return res, nil
+}
+
 // GenerateCRDResourcePackageFile returns a byte buffer containing the output of
 // an executed template containing a file in a specific CRD's resource package
 func (g *Generator) GenerateCRDResourcePackageFile(
@@ -396,7 +558,10 @@ func (g *Generator) GenerateConfigYAMLFile(
 	if !found {
 		return nil, errUnknownTemplate(targetPath)
 	}
-	vars := g.templateMetaVars()
+	vars, err := g.templateConfigVars()
+	if err != nil {
+		return nil, err
+	}
 	var b bytes.Buffer
 	if err := t.Execute(&b, vars); err != nil {
 		return nil, err
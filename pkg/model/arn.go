@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
+)
+
+// arnTemplatePlaceholder matches the placeholders in ARN templates
+var arnTemplatePlaceholder = regexp.MustCompile(`\{([A-Za-z0-9]+)\}`)
+
+// ARNTemplateField is a field of a resource whose value replaces a
+// placeholder in the resource's ARN template
+type ARNTemplateField struct {
+	// Name is the original SDK member name of the field, e.g. "ApiId", which
+	// is the field's placeholder in the ARN template without the braces
+	Name string
+	// Path is the path of the Spec or Status field, e.g. "Status.APIID"
+	Path string
+}
+
+// ARNTemplate returns the template of the resource's Amazon Resource Name
+// (ARN), from the generator config if configured there, or else the default
+// template for the service, which has no resource type segment
+func (r *CRD) ARNTemplate() string {
+	tmpl := r.genCfg.ResourceARNTemplate(r.Names.Original)
+	if tmpl == "" {
+		return ackarn.DefaultTemplate(r.sdkAPI.ServiceIDClean())
+	}
+	if !strings.Contains(tmpl, ackarn.PlaceholderName) &&
+		len(r.ARNTemplateFields()) == 0 {
+		msg := fmt.Sprintf(
+			"ARN template %q configured for resource %s has no %s or field placeholder",
+			tmpl, r.Names.Original, ackarn.PlaceholderName,
+		)
+		panic(msg)
+	}
+	return tmpl
+}
+
+// ARNTemplateFields returns the fields of the resource, sorted by name, whose
+// values replace placeholders in the resource's ARN template other than the
+// {partition}, {region}, {account} and {name} placeholders, e.g. the "ApiId"
+// field for the {ApiId} placeholder
+func (r *CRD) ARNTemplateFields() []ARNTemplateField {
+	tmpl := r.genCfg.ResourceARNTemplate(r.Names.Original)
+	fields := []ARNTemplateField{}
+	seen := map[string]bool{}
+	for _, match := range arnTemplatePlaceholder.FindAllStringSubmatch(tmpl, -1) {
+		fieldName := match[1]
+		switch match[0] {
+		case ackarn.PlaceholderPartition, ackarn.PlaceholderRegion,
+			ackarn.PlaceholderAccountID, ackarn.PlaceholderName:
+			continue
+		}
+		if seen[fieldName] {
+			continue
+		}
+		seen[fieldName] = true
+		if specField, found := r.SpecFields[fieldName]; found &&
+			specField.GoType == "*string" {
+			fields = append(fields, ARNTemplateField{
+				fieldName, "Spec." + specField.Names.Camel,
+			})
+			continue
+		}
+		if statusField, found := r.StatusFields[fieldName]; found &&
+			statusField.GoType == "*string" {
+			fields = append(fields, ARNTemplateField{
+				fieldName, "Status." + statusField.Names.Camel,
+			})
+			continue
+		}
+		msg := fmt.Sprintf(
+			"ARN template %q configured for resource %s has placeholder %s which is not a string field in the Spec or Status",
+			tmpl, r.Names.Original, match[0],
+		)
+		panic(msg)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+// ARNTemplateNameField returns the Spec field whose value replaces the {name}
+// placeholder in the resource's ARN template, or nil if the template has no
+// {name} placeholder
+func (r *CRD) ARNTemplateNameField() *CRDField {
+	if !strings.Contains(r.ARNTemplate(), ackarn.PlaceholderName) {
+		return nil
+	}
+	return r.SpecNameField()
+}
+
+// ARNTemplateNameOnlyField returns the field placeholder in the resource's
+// ARN template when it's the only placeholder other than the {partition},
+// {region} and {account} placeholders and it names the resource's name
+// field, e.g. the {TopicName} placeholder, or nil otherwise. ARNFromName
+// can only build the ARN of resources whose ARN templates have no field
+// placeholders or only this one.
+func (r *CRD) ARNTemplateNameOnlyField() *ARNTemplateField {
+	fields := r.ARNTemplateFields()
+	if len(fields) != 1 || r.ARNTemplateNameField() != nil {
+		return nil
+	}
+	if fields[0].Name != r.NameField() {
+		return nil
+	}
+	return &fields[0]
+}
+
+// goCodeARN returns the Go code for the ARN of the CR in the supplied
+// variable, for use when the CR's Status has no ARN yet, e.g.
+// "rm.ARNFromName(*ko.Spec.Name)", or "rm.arnFromCR(ko)" when the resource's
+// ARN template has field placeholders
+func (r *CRD) goCodeARN(sourceVarName string) string {
+	if len(r.ARNTemplateFields()) > 0 {
+		return fmt.Sprintf("rm.arnFromCR(%s)", sourceVarName)
+	}
+	return fmt.Sprintf("rm.ARNFromName(*%s.Spec.%s)", sourceVarName, r.NameField())
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-controllers-k8s/pkg/names"
+)
+
+// ChildKinds returns the kinds of the resource's child resources, in the
+// order they're deleted, or nil if the resource has no child resources
+func (r *CRD) ChildKinds() []string {
+	childrenConfig := r.genCfg.ResourceChildrenConfig(r.Names.Original)
+	if childrenConfig == nil {
+		return nil
+	}
+	kinds := make([]string, 0, len(childrenConfig.Resources))
+	for _, childName := range childrenConfig.Resources {
+		kinds = append(kinds, names.New(childName).Camel)
+	}
+	return kinds
+}
+
+// ChildIdentifierPath returns the path of the Spec or Status field, e.g.
+// "Status.APIID", containing the identifier by which the resource's child
+// resources refer to it, or the empty string if the resource has no child
+// resources
+func (r *CRD) ChildIdentifierPath() string {
+	childrenConfig := r.genCfg.ResourceChildrenConfig(r.Names.Original)
+	if childrenConfig == nil {
+		return ""
+	}
+	if specField, found := r.SpecFields[childrenConfig.Field]; found &&
+		specField.GoType == "*string" {
+		return "Spec." + specField.Names.Camel
+	}
+	if statusField, found := r.StatusFields[childrenConfig.Field]; found &&
+		statusField.GoType == "*string" {
+		return "Status." + statusField.Names.Camel
+	}
+	msg := fmt.Sprintf(
+		"children field %s configured for resource %s is not a string field in the Spec or Status",
+		childrenConfig.Field, r.Names.Original,
+	)
+	panic(msg)
+}
+
+// ParentKind returns the kind of the resource's parent resource, or the empty
+// string if the resource isn't a child resource
+func (r *CRD) ParentKind() string {
+	parentName, _ := r.genCfg.ResourceParent(r.Names.Original)
+	if parentName == "" {
+		return ""
+	}
+	return names.New(parentName).Camel
+}
+
+// ParentIdentifierField returns the Spec field containing the identifier of
+// the resource's parent resource, or nil if the resource isn't a child
+// resource
+func (r *CRD) ParentIdentifierField() *CRDField {
+	parentName, childrenConfig := r.genCfg.ResourceParent(r.Names.Original)
+	if parentName == "" {
+		return nil
+	}
+	specField, found := r.SpecFields[childrenConfig.Field]
+	if !found || specField.GoType != "*string" {
+		msg := fmt.Sprintf(
+			"children field %s configured for resource %s is not a string field in the Spec of child resource %s",
+			childrenConfig.Field, parentName, r.Names.Original,
+		)
+		panic(msg)
+	}
+	return specField
+}
@@ -80,7 +80,20 @@ func newCRDField(
 	if shapeRef != nil {
 		shape = shapeRef.Shape
 	}
-	if shape != nil {
+	if cfg != nil && cfg.IsSecret {
+		if shape != nil && shape.Type != "string" {
+			msg := fmt.Sprintf(
+				"field %s of %s is a secret but isn't a string",
+				fieldNames.Original, crd.Names.Original,
+			)
+			panic(msg)
+		}
+		// Secret fields refer to a key in a Kubernetes Secret holding the
+		// value instead of containing the value
+		gte = "ackv1alpha1.SecretKeyReference"
+		gt = "*ackv1alpha1.SecretKeyReference"
+		gtwp = "*ackv1alpha1.SecretKeyReference"
+	} else if shape != nil {
 		gte, gt, gtwp = crd.cleanGoType(shape)
 	} else {
 		gte = "string"
@@ -98,6 +111,12 @@ func newCRDField(
 	}
 }
 
+// IsSecret returns true if the field refers to a key in a Kubernetes Secret
+// holding the field's value
+func (f *CRDField) IsSecret() bool {
+	return f.FieldConfig != nil && f.FieldConfig.IsSecret
+}
+
 // CRD describes a single top-level resource in an AWS service API
 type CRD struct {
 	sdkAPI *SDKAPI
@@ -123,8 +142,16 @@ type CRD struct {
 // HasShapeAsMember returns true if the supplied Shape name appears in *any*
 // payload shape of *any* Operation for the resource. It recurses down through
 // the resource's Operation Input and Output shapes and their member shapes
-// looking for a shape with the supplied name
+// looking for a shape with the supplied name, as well as the shapes of Spec
+// fields that don't come from those payloads, e.g. the Tags field added by
+// AddTagField
 func (r *CRD) HasShapeAsMember(toFind string) bool {
+	for _, field := range r.SpecFields {
+		if field.ShapeRef != nil && field.ShapeRef.Shape != nil &&
+			shapeHasMember(field.ShapeRef.Shape, toFind) {
+			return true
+		}
+	}
 	for _, op := range r.Ops.IterOps() {
 		if op.InputRef.Shape != nil {
 			inShape := op.InputRef.Shape
@@ -230,7 +257,8 @@ func (r *CRD) AddSpecField(
 	memberNames names.Names,
 	shapeRef *awssdkmodel.ShapeRef,
 ) {
-	crdField := newCRDField(r, memberNames, shapeRef, nil)
+	cfg := r.genCfg.ResourceFieldConfig(r.Names.Original, memberNames.Original)
+	crdField := newCRDField(r, memberNames, shapeRef, cfg)
 	r.SpecFields[memberNames.Original] = crdField
 }
 
@@ -256,6 +284,25 @@ func (r *CRD) AddTypeImport(
 	r.TypeImports[packagePath] = alias
 }
 
+// SecretFields returns the Spec fields referring to keys in Kubernetes
+// Secrets, sorted by field name
+func (r *CRD) SecretFields() []*CRDField {
+	res := []*CRDField{}
+	for _, fieldName := range r.SpecFieldNames() {
+		field := r.SpecFields[fieldName]
+		if field.IsSecret() {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// HasSecretFields returns true if any of the CRD's Spec fields refer to keys
+// in Kubernetes Secrets
+func (r *CRD) HasSecretFields() bool {
+	return len(r.SecretFields()) > 0
+}
+
 // SpecFieldNames returns a sorted slice of field names for the Spec fields
 func (r *CRD) SpecFieldNames() []string {
 	res := make([]string, 0, len(r.SpecFields))
@@ -683,16 +730,32 @@ func (r *CRD) GoCodeSetInput(
 			out += fmt.Sprintf(
 				"%s} else {\n", indent,
 			)
-			nameField := r.NameField()
 			out += fmt.Sprintf(
-				"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
-				indent, targetVarName, memberName, sourceVarName, nameField,
+				"%s\t%s.Set%s(%s)\n",
+				indent, targetVarName, memberName, r.goCodeARN(sourceVarName),
 			)
 			out += fmt.Sprintf(
 				"%s}\n", indent,
 			)
 			continue
 		}
+		if IsIdempotencyToken(memberName, inputShape.MemberRefs[memberName]) {
+			if opType == OpTypeCreate {
+				// The CR's UID uniquely identifies the resource, so retrying
+				// a Create call after the service controller fails to record
+				// the result, e.g. because it restarted, returns the
+				// resource that was already created instead of creating a
+				// duplicate.
+				//
+				// For other operations, the aws-sdk-go generates a random
+				// token when the member isn't set.
+				out += fmt.Sprintf(
+					"%s%s.Set%s(string(%s.GetUID()))\n",
+					indent, targetVarName, memberName, sourceVarName,
+				)
+			}
+			continue
+		}
 		renamedName, _ := r.InputFieldRename(op.Name, memberName)
 		// Determine whether the input shape's field is in the Spec or the
 		// Status struct and set the source variable appropriately.
@@ -717,6 +780,19 @@ func (r *CRD) GoCodeSetInput(
 		if r.genCfg.IsIgnoredShape(memberShape.ShapeName) {
 			continue
 		}
+		if crdField.IsSecret() {
+			if opType == OpTypeCreate || opType == OpTypeUpdate {
+				out += goCodeSetInputForSecret(
+					memberName,
+					targetVarName,
+					sourceAdaptedVarName,
+					indentLevel,
+				)
+			}
+			// The value of a secret field is only sent to the backend AWS
+			// service API when creating or updating the resource
+			continue
+		}
 
 		// we construct variables containing temporary storage for sub-elements
 		// and sub-fields that are structs. Names of fields are "f" appended by
@@ -818,6 +894,47 @@ func (r *CRD) GoCodeSetInput(
 	return out
 }
 
+// goCodeSetInputForSecret returns the Go code that sets an Input shape's
+// member to the value of the Kubernetes Secret key referred to by a secret
+// field, e.g.:
+//
+//   if r.ko.Spec.AuthToken != nil {
+//       tmpSecret, err := rm.rr.SecretValueFromReference(ctx, r.ko.Spec.AuthToken)
+//       if err != nil {
+//           return nil, err
+//       }
+//       if tmpSecret != "" {
+//           res.SetAuthToken(tmpSecret)
+//       }
+//   }
+func goCodeSetInputForSecret(
+	// The name of the SDK Input shape member we're outputting for
+	memberName string,
+	// The variable name that we want to set a value to
+	targetVarName string,
+	// The struct or struct field that we access our source value from
+	sourceVarName string,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	out += fmt.Sprintf("%sif %s != nil {\n", indent, sourceVarName)
+	out += fmt.Sprintf(
+		"%s\ttmpSecret, err := rm.rr.SecretValueFromReference(ctx, %s)\n",
+		indent, sourceVarName,
+	)
+	out += fmt.Sprintf("%s\tif err != nil {\n", indent)
+	out += fmt.Sprintf("%s\t\treturn nil, err\n", indent)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s\tif tmpSecret != \"\" {\n", indent)
+	out += fmt.Sprintf(
+		"%s\t\t%s.Set%s(tmpSecret)\n", indent, targetVarName, memberName,
+	)
+	out += fmt.Sprintf("%s\t}\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
 // GoCodeGetAttributesSetInput returns the Go code that sets the Input shape for a
 // resource's GetAttributes operation.
 //
@@ -881,10 +998,9 @@ func (r *CRD) GoCodeGetAttributesSetInput(
 			out += fmt.Sprintf(
 				"%s} else {\n", indent,
 			)
-			nameField := r.NameField()
 			out += fmt.Sprintf(
-				"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
-				indent, targetVarName, memberName, sourceVarName, nameField,
+				"%s\t%s.Set%s(%s)\n",
+				indent, targetVarName, memberName, r.goCodeARN(sourceVarName),
 			)
 			out += fmt.Sprintf(
 				"%s}\n", indent,
@@ -907,6 +1023,9 @@ func (r *CRD) GoCodeGetAttributesSetInput(
 			}
 			sourceVarPath = sourceVarPath + ".Status." + cleanMemberName
 		}
+		if field.IsSecret() {
+			continue
+		}
 		out += fmt.Sprintf(
 			"%sif %s != nil {\n",
 			indent, sourceVarPath,
@@ -1013,10 +1132,9 @@ func (r *CRD) GoCodeSetAttributesSetInput(
 			out += fmt.Sprintf(
 				"%s} else {\n", indent,
 			)
-			nameField := r.NameField()
 			out += fmt.Sprintf(
-				"%s\t%s.Set%s(rm.ARNFromName(*%s.Spec.%s))\n",
-				indent, targetVarName, memberName, sourceVarName, nameField,
+				"%s\t%s.Set%s(%s)\n",
+				indent, targetVarName, memberName, r.goCodeARN(sourceVarName),
 			)
 			out += fmt.Sprintf(
 				"%s}\n", indent,
@@ -1093,6 +1211,9 @@ func (r *CRD) GoCodeSetAttributesSetInput(
 			}
 			sourceVarPath = sourceVarPath + ".Status." + cleanMemberName
 		}
+		if field.IsSecret() {
+			continue
+		}
 		out += fmt.Sprintf(
 			"%sif %s != nil {\n",
 			indent, sourceVarPath,
@@ -1135,6 +1256,17 @@ func (r *CRD) NameField() string {
 	return "???"
 }
 
+// SpecNameField returns the Spec field containing the name of the resource,
+// as returned by NameField(), or nil if the resource has no string Spec field
+// for its name
+func (r *CRD) SpecNameField() *CRDField {
+	specField, found := r.SpecFields[r.NameField()]
+	if !found || specField.GoType != "*string" {
+		return nil
+	}
+	return specField
+}
+
 func (r *CRD) goCodeSetInputForContainer(
 	// The name of the SDK Input shape member we're outputting for
 	targetFieldName string,
@@ -1719,24 +1851,7 @@ func (r *CRD) goCodeSetOutputReadMany(
 	out := "\n"
 	indent := strings.Repeat("\t", indentLevel)
 
-	listShapeName := ""
-	var elemShape *awssdkmodel.Shape
-
-	// Find the element in the output shape that contains the list of
-	// resources. This heuristic is simplistic (just look for the field with a
-	// list type) but seems to be followed consistently by the aws-sdk-go for
-	// List operations.
-	for memberName, memberShapeRef := range outputShape.MemberRefs {
-		if memberShapeRef.Shape.Type == "list" {
-			listShapeName = memberName
-			elemShape = memberShapeRef.Shape.MemberRef.Shape
-			break
-		}
-	}
-
-	if listShapeName == "" {
-		panic("List output shape had no field of type 'list'")
-	}
+	listShapeName, elemShape := readManyListMember(op)
 
 	// Set of field names in the element shape that, if the generator config
 	// instructs us to, we will write Go code to filter results of the List
@@ -1760,6 +1875,73 @@ func (r *CRD) goCodeSetOutputReadMany(
 		"%sfor _, elem := range %s.%s {\n",
 		indent, sourceVarName, listShapeName,
 	)
+	out += r.goCodeSetOutputReadManyElem(
+		elemShape, targetVarName, matchFieldNames, false, indentLevel,
+	)
+	// When we don't have custom matching/filtering logic for the list
+	// operation, we just take the first element in the returned slice
+	// of objects. When we DO have match fields, the generated Go code
+	// above will output a `continue` when the required fields don't
+	// match. Thus, we will break here only when getting a record where
+	// all match fields have matched.
+	out += fmt.Sprintf(
+		"%s\tfound = true\n", indent,
+	)
+	out += fmt.Sprintf(
+		"%s\tbreak\n", indent,
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	//  if !found {
+	//      return nil, ackerr.NotFound
+	//  }
+	out += fmt.Sprintf("%sif !found {\n", indent)
+	out += fmt.Sprintf("%s\treturn nil, ackerr.NotFound\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// readManyListMember returns the name of the member of the supplied ReadMany
+// operation's Output shape that contains the list of resources, and the shape
+// of the list's elements.
+func readManyListMember(
+	op *awssdkmodel.Operation,
+) (string, *awssdkmodel.Shape) {
+	outputShape := op.OutputRef.Shape
+	// Find the element in the output shape that contains the list of
+	// resources. This heuristic is simplistic (just look for the field with a
+	// list type) but seems to be followed consistently by the aws-sdk-go for
+	// List operations.
+	for memberName, memberShapeRef := range outputShape.MemberRefs {
+		if memberShapeRef.Shape.Type == "list" {
+			return memberName, memberShapeRef.Shape.MemberRef.Shape
+		}
+	}
+	panic("List output shape had no field of type 'list'")
+}
+
+// goCodeSetOutputReadManyElem returns the Go code that sets the supplied
+// target variable from the fields of a single element, named "elem", of the
+// list of resources returned by a List operation. When the values of the
+// supplied match fields in the target variable's Spec are not nil and differ
+// from the element's, the code continues to the next element. Members of the
+// element whose type differs from the type of the CRD field, e.g. because the
+// CRD field comes from the Input shape of the Create operation, are skipped
+// if requested.
+func (r *CRD) goCodeSetOutputReadManyElem(
+	// The shape of the elements of the list of resources
+	elemShape *awssdkmodel.Shape,
+	// String representing the name of the variable that we will be **setting**
+	targetVarName string,
+	// Names of the fields to match against the target variable's values
+	matchFieldNames []string,
+	// Whether to skip the members whose type differs from the type of the
+	// CRD field with the same name
+	skipMismatchedTypes bool,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
 	for memberIndex, memberName := range elemShape.MemberNames() {
 		memberShapeRef := elemShape.MemberRefs[memberName]
 		memberShape := memberShapeRef.Shape
@@ -1818,6 +2000,18 @@ func (r *CRD) goCodeSetOutputReadMany(
 			}
 			targetAdaptedVarName += ".Status"
 		}
+		if crdField.IsSecret() {
+			// The backend AWS service API doesn't return sensitive values
+			continue
+		}
+		if skipMismatchedTypes && isContainerShape(memberShape) &&
+			crdField.ShapeRef != nil &&
+			crdField.ShapeRef.Shape.ShapeName != memberShape.ShapeName {
+			// The element's member is a different type than the CRD field,
+			// which is the case when the CRD field comes from the Input shape
+			// of the Create operation, so we can't copy it over
+			continue
+		}
 		out += fmt.Sprintf(
 			"%s\tif %s != nil {\n", indent, sourceAdaptedVarName,
 		)
@@ -1888,25 +2082,6 @@ func (r *CRD) goCodeSetOutputReadMany(
 			"%s\t}\n", indent,
 		)
 	}
-	// When we don't have custom matching/filtering logic for the list
-	// operation, we just take the first element in the returned slice
-	// of objects. When we DO have match fields, the generated Go code
-	// above will output a `continue` when the required fields don't
-	// match. Thus, we will break here only when getting a record where
-	// all match fields have matched.
-	out += fmt.Sprintf(
-		"%s\tfound = true\n", indent,
-	)
-	out += fmt.Sprintf(
-		"%s\tbreak\n", indent,
-	)
-	out += fmt.Sprintf("%s}\n", indent)
-	//  if !found {
-	//      return nil, ackerr.NotFound
-	//  }
-	out += fmt.Sprintf("%sif !found {\n", indent)
-	out += fmt.Sprintf("%s\treturn nil, ackerr.NotFound\n", indent)
-	out += fmt.Sprintf("%s}\n", indent)
 	return out
 }
 
@@ -0,0 +1,41 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+// idempotencyTokenMemberNames contains the names of shape members that
+// contain idempotency tokens in API models that don't mark them as such
+var idempotencyTokenMemberNames = map[string]bool{
+	"ClientToken":        true,
+	"ClientRequestToken": true,
+	"IdempotencyToken":   true,
+}
+
+// IsIdempotencyToken returns true if the supplied shape member contains an
+// idempotency token, e.g. the `ClientToken` member of many EC2 Input shapes.
+// These members are not part of a resource's desired state and don't become
+// CRD fields.
+func IsIdempotencyToken(
+	memberName string,
+	ref *awssdkmodel.ShapeRef,
+) bool {
+	if ref.IdempotencyToken || (ref.Shape != nil && ref.Shape.IdempotencyToken) {
+		return true
+	}
+	return idempotencyTokenMemberNames[memberName] &&
+		ref.Shape != nil && ref.Shape.Type == "string"
+}
@@ -0,0 +1,143 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+)
+
+var (
+	// listPaginationTokens maps the names of the Input shape members that
+	// List operations take the token of the next page of results in to the
+	// names of the Output shape members that may contain the token
+	listPaginationTokens = map[string][]string{
+		"NextToken": {"NextToken"},
+		"Marker":    {"Marker", "NextMarker"},
+	}
+)
+
+// ListPagination describes how the List operation of a resource returns its
+// results in pages
+type ListPagination struct {
+	// InputTokenMember is the name of the Input shape member containing the
+	// token of the page of results to return
+	InputTokenMember string
+	// OutputTokenMember is the name of the Output shape member containing
+	// the token of the next page of results. It's nil or empty on the last
+	// page.
+	OutputTokenMember string
+}
+
+// CanReadAll returns true if the resource's List operation can be used to
+// list all the resources of its kind, which is the case when the operation's
+// Input shape has no required members
+func (r *CRD) CanReadAll() bool {
+	op := r.Ops.ReadMany
+	if op == nil || op.InputRef.Shape == nil || op.OutputRef.Shape == nil {
+		return false
+	}
+	return len(op.InputRef.Shape.Required) == 0
+}
+
+// ReadManyListMemberName returns the name of the member of the List
+// operation's Output shape that contains the list of resources
+func (r *CRD) ReadManyListMemberName() string {
+	if !r.CanReadAll() {
+		return ""
+	}
+	name, _ := readManyListMember(r.Ops.ReadMany)
+	return name
+}
+
+// ReadManyFilterCustomMethodName returns the name of the custom method on
+// the `resourceManager` struct that filters the resources returned by the
+// List operation, or "" if the generator config doesn't specify one
+func (r *CRD) ReadManyFilterCustomMethodName() string {
+	if r.genCfg == nil {
+		return ""
+	}
+	rConfig, found := r.genCfg.Resources[r.Names.Original]
+	if !found || rConfig.ListOperation == nil {
+		return ""
+	}
+	return rConfig.ListOperation.FilterCustomMethodName
+}
+
+// ReadManyPagination returns a ListPagination describing how the resource's
+// List operation pages its results, or nil if the operation returns all the
+// results at once
+func (r *CRD) ReadManyPagination() *ListPagination {
+	if !r.CanReadAll() {
+		return nil
+	}
+	inShape := r.Ops.ReadMany.InputRef.Shape
+	outShape := r.Ops.ReadMany.OutputRef.Shape
+	for inMember, outMembers := range listPaginationTokens {
+		if !hasStringMember(inShape, inMember) {
+			continue
+		}
+		for _, outMember := range outMembers {
+			if hasStringMember(outShape, outMember) {
+				return &ListPagination{
+					InputTokenMember:  inMember,
+					OutputTokenMember: outMember,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GoCodeSetReadManyElemOutput returns the Go code that sets the supplied
+// target variable from the fields of a single element, named "elem", of the
+// list of resources returned by the resource's List operation.
+//
+// Sample output:
+//
+//	if elem.RepositoryName != nil {
+//		ko.Spec.RepositoryName = elem.RepositoryName
+//	}
+func (r *CRD) GoCodeSetReadManyElemOutput(
+	// String representing the name of the variable that we will be **setting**
+	targetVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	if !r.CanReadAll() {
+		return ""
+	}
+	_, elemShape := readManyListMember(r.Ops.ReadMany)
+	// goCodeSetOutputReadManyElem indents the code it returns by one more
+	// level than it's asked to, because it expects to be in a for loop
+	return r.goCodeSetOutputReadManyElem(
+		elemShape, targetVarName, nil, true, indentLevel-1,
+	)
+}
+
+// hasStringMember returns true if the supplied shape has a string member with
+// the supplied name
+func hasStringMember(shape *awssdkmodel.Shape, memberName string) bool {
+	ref, found := shape.MemberRefs[memberName]
+	return found && ref.Shape != nil && ref.Shape.Type == "string"
+}
+
+// isContainerShape returns true if the supplied shape is a list, structure or
+// map
+func isContainerShape(shape *awssdkmodel.Shape) bool {
+	switch shape.Type {
+	case "list", "structure", "map":
+		return true
+	}
+	return false
+}
@@ -14,6 +14,7 @@
 package model
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -77,11 +78,33 @@ func (h *SDKHelper) API(serviceAlias string) (*SDKAPI, error) {
 		// Calling API.ServicePackageDoc() ends up resetting the API.imports
 		// unexported map variable...
 		_ = api.ServicePackageDoc()
-		return &SDKAPI{api, nil, nil}, nil
+		constraints, err := loadShapeConstraints(modelPath)
+		if err != nil {
+			return nil, err
+		}
+		return &SDKAPI{api, nil, nil, constraints}, nil
 	}
 	return nil, ErrServiceNotFound
 }
 
+// loadShapeConstraints reads the raw API model file and returns a map, keyed
+// by shape name, of the value constraints for the shape. The aws-sdk-go model
+// loader only keeps the `min` constraint of a shape and drops the `max` and
+// `pattern` constraints, which we need to generate validation code.
+func loadShapeConstraints(modelPath string) (map[string]*ShapeConstraints, error) {
+	contents, err := ioutil.ReadFile(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	raw := struct {
+		Shapes map[string]*ShapeConstraints `json:"shapes"`
+	}{}
+	if err = json.Unmarshal(contents, &raw); err != nil {
+		return nil, err
+	}
+	return raw.Shapes, nil
+}
+
 // ModelAndDocsPath returns two string paths to the supplied service alias'
 // model and doc JSON files
 func (h *SDKHelper) ModelAndDocsPath(
@@ -132,6 +155,30 @@ type SDKAPI struct {
 	// Map, keyed by original Shape GoTypeElem(), with the values being a
 	// renamed type name (due to conflicting names)
 	typeRenames map[string]string
+	// Map, keyed by Shape name, of the value constraints that the API model
+	// declares for the shape
+	shapeConstraints map[string]*ShapeConstraints
+}
+
+// ShapeConstraints describes the constraints placed by the API model on the
+// values of a shape
+type ShapeConstraints struct {
+	// Min is the minimum length (string, list) or value (number) of the shape
+	Min *float64 `json:"min,omitempty"`
+	// Max is the maximum length (string, list) or value (number) of the shape
+	Max *float64 `json:"max,omitempty"`
+	// Pattern is a regular expression that string values of the shape must
+	// match
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// GetShapeConstraints returns the value constraints for the supplied shape
+// name, or nil if the API model does not constrain the shape's values
+func (a *SDKAPI) GetShapeConstraints(shapeName string) *ShapeConstraints {
+	if a == nil || a.shapeConstraints == nil {
+		return nil
+	}
+	return a.shapeConstraints[shapeName]
 }
 
 // GetPayloads returns a slice of strings of Shape names representing input and
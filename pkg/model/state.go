@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	ackgenconfig "github.com/aws/aws-controllers-k8s/pkg/generate/config"
+	"github.com/aws/aws-controllers-k8s/pkg/names"
+)
+
+// StateConfig returns the instructions for determining whether the resource
+// is in a stable state, or nil if the resource doesn't go through
+// transitional states
+func (r *CRD) StateConfig() *ackgenconfig.StateConfig {
+	return r.genCfg.ResourceStateConfig(r.Names.Original)
+}
+
+// StateField returns the Status or Spec field containing the state of the
+// resource, or the first field of the path to its state when the state is
+// nested in the field, or nil if the resource doesn't go through transitional
+// states
+func (r *CRD) StateField() *CRDField {
+	stateConfig := r.StateConfig()
+	if stateConfig == nil {
+		return nil
+	}
+	path := strings.Split(stateConfig.Field, ".")
+	if len(path) == 1 {
+		statusField, found := r.StatusFields[stateConfig.Field]
+		if !found || statusField.GoType != "*string" {
+			msg := fmt.Sprintf(
+				"state field %s configured for resource %s is not a string field in the Status",
+				stateConfig.Field, r.Names.Original,
+			)
+			panic(msg)
+		}
+		return statusField
+	}
+	field, found := r.StatusFields[path[0]]
+	if !found {
+		field, found = r.SpecFields[path[0]]
+	}
+	if !found || field.ShapeRef == nil || field.ShapeRef.Shape == nil {
+		msg := fmt.Sprintf(
+			"state field %s configured for resource %s is not a field in the Status or Spec",
+			stateConfig.Field, r.Names.Original,
+		)
+		panic(msg)
+	}
+	return field
+}
+
+// GoCodeState returns the Go code for the body of the generated resource's
+// State method, which returns the value of the resource's state field found
+// in the CR in the supplied variable, or the empty string if it isn't set.
+// When the path to a nested state field goes through a list, the state of
+// the list's first element is returned; the resources with nested state
+// fields, e.g. API Gateway DomainNames, have lists of a single element.
+func (r *CRD) GoCodeState(
+	// String representing the name of the variable containing the CR
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	field := r.StateField()
+	if field == nil {
+		return ""
+	}
+	indent := strings.Repeat("\t", indentLevel)
+	container := "Spec"
+	if _, found := r.StatusFields[field.Names.Original]; found {
+		container = "Status"
+	}
+	varName := fmt.Sprintf("%s.%s.%s", koVarName, container, field.Names.Camel)
+	path := strings.Split(r.StateConfig().Field, ".")
+	nilChecks := []string{}
+	var shape *awssdkmodel.Shape
+	if field.ShapeRef != nil {
+		shape = field.ShapeRef.Shape
+	}
+	for _, memberName := range path[1:] {
+		if shape != nil && shape.Type == "list" {
+			nilChecks = append(nilChecks, fmt.Sprintf("len(%s) == 0", varName))
+			varName += "[0]"
+			shape = shape.MemberRef.Shape
+		}
+		if shape == nil || shape.Type != "structure" {
+			break
+		}
+		memberRef, found := shape.MemberRefs[memberName]
+		if !found {
+			msg := fmt.Sprintf(
+				"state field %s configured for resource %s has no member %s",
+				r.StateConfig().Field, r.Names.Original, memberName,
+			)
+			panic(msg)
+		}
+		nilChecks = append(nilChecks, fmt.Sprintf("%s == nil", varName))
+		varName += "." + names.New(memberName).Camel
+		shape = memberRef.Shape
+	}
+	if len(path) > 1 && (shape == nil || shape.Type != "string") {
+		msg := fmt.Sprintf(
+			"state field %s configured for resource %s is not a string field",
+			r.StateConfig().Field, r.Names.Original,
+		)
+		panic(msg)
+	}
+	nilChecks = append(nilChecks, fmt.Sprintf("%s == nil", varName))
+	out := fmt.Sprintf(
+		"%sif %s {\n", indent,
+		strings.Join(nilChecks, " ||\n"+indent+"\t"),
+	)
+	out += fmt.Sprintf("%s\treturn \"\"\n", indent)
+	out += fmt.Sprintf("%s}\n", indent)
+	out += fmt.Sprintf("%sreturn *%s\n", indent, varName)
+	return out
+}
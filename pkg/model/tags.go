@@ -0,0 +1,290 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws/aws-controllers-k8s/pkg/names"
+)
+
+const (
+	// defaultTagFieldName is the name of the Spec field that contains a
+	// resource's tags when the generator config doesn't specify one
+	defaultTagFieldName = "Tags"
+)
+
+// TagOps contains the operations that read and modify the tags of a resource
+type TagOps struct {
+	// List is the operation that returns the tags of a resource
+	List *TagOp
+	// Tag is the operation that adds tags to, or overwrites existing tags on,
+	// a resource. If the Untag operation removes all of the resource's tags,
+	// the Tag operation replaces the whole set of tags.
+	Tag *TagOp
+	// Untag is the operation that removes tags from a resource
+	Untag *TagOp
+	// NoTagsErrorCode is the error code the List operation returns when the
+	// resource has no tags, if the operation doesn't return an empty set
+	NoTagsErrorCode string
+}
+
+// TagOp describes an operation that reads or modifies the tags of a resource
+type TagOp struct {
+	Operation *awssdkmodel.Operation
+	// IdentifierMember is the name of the Input shape member that identifies
+	// the resource by its ARN or, if IdentifierIsName is true, by its name
+	IdentifierMember string
+	// IdentifierIsName is true if the IdentifierMember contains the name of
+	// the resource rather than its ARN, e.g. the Bucket member of the S3
+	// tagging operations
+	IdentifierIsName bool
+	// TagsMember is the name of the shape member containing the tags (the
+	// Output shape of a List operation and the Input shape of a Tag
+	// operation) or the keys of the tags (the Input shape of an Untag
+	// operation). It is empty for an Untag operation that removes all of
+	// the resource's tags.
+	TagsMember string
+	// TagsContainerMember is the name of the Input shape's structure member
+	// that contains the TagsMember, for Tag operations that don't take the
+	// tags directly, e.g. the Tagging member of S3's PutBucketTagging
+	TagsContainerMember string
+	// TagsContainerShapeName is the name of the shape of the
+	// TagsContainerMember
+	TagsContainerShapeName string
+	// TagsIsMap is true if the tags are a `map[string]*string` rather than a
+	// list of structs with Key and Value members
+	TagsIsMap bool
+	// TagShapeName is the name of the shape of the structs in a list of tags
+	TagShapeName string
+}
+
+// RemovesAllTags returns true if the supplied Untag operation removes all of
+// the resource's tags rather than the tags with the supplied keys
+func (op *TagOp) RemovesAllTags() bool {
+	return op.TagsMember == ""
+}
+
+// TagField returns the Spec field containing the resource's tags, or nil if
+// the resource has no such field or the field isn't either a map of strings
+// or a list of structs with Key and Value members
+func (r *CRD) TagField() *CRDField {
+	fieldName := defaultTagFieldName
+	tagsConfig := r.genCfg.ResourceTagsConfig(r.Names.Original)
+	if tagsConfig != nil && tagsConfig.Field != nil {
+		fieldName = *tagsConfig.Field
+	}
+	specField, found := r.SpecFields[fieldName]
+	if !found || specField.ShapeRef == nil || specField.ShapeRef.Shape == nil {
+		return nil
+	}
+	if !isTagsShape(specField.ShapeRef.Shape) {
+		return nil
+	}
+	return specField
+}
+
+// TagFieldIsMap returns true if the Spec field containing the resource's tags
+// is a `map[string]*string` rather than a list of structs with Key and Value
+// members
+func (r *CRD) TagFieldIsMap() bool {
+	tagField := r.TagField()
+	return tagField != nil && tagField.ShapeRef.Shape.Type == "map"
+}
+
+// TagOps returns the operations that read and modify the tags of the
+// resource, or nil if the generator config doesn't instruct the generator to
+// keep the resource's tags in sync using tagging operations.
+func (r *CRD) TagOps() *TagOps {
+	tagsConfig := r.genCfg.ResourceTagsConfig(r.Names.Original)
+	if tagsConfig == nil {
+		return nil
+	}
+	if r.TagField() == nil {
+		msg := fmt.Sprintf(
+			"tags configured for resource %s but it has no Spec field containing tags",
+			r.Names.Original,
+		)
+		panic(msg)
+	}
+	tagOps := &TagOps{
+		List:  r.newTagOp(tagsConfig.ListOperation, tagOpTypeList),
+		Tag:   r.newTagOp(tagsConfig.TagOperation, tagOpTypeTag),
+		Untag: r.newTagOp(tagsConfig.UntagOperation, tagOpTypeUntag),
+	}
+	if tagsConfig.NoTagsErrorCode != nil {
+		tagOps.NoTagsErrorCode = *tagsConfig.NoTagsErrorCode
+	}
+	return tagOps
+}
+
+// CreateSetsTags returns true if the resource's Create operation accepts the
+// tags in the resource's tags Spec field
+func (r *CRD) CreateSetsTags() bool {
+	tagField := r.TagField()
+	if tagField == nil || r.Ops.Create == nil {
+		return false
+	}
+	for memberName := range r.Ops.Create.InputRef.Shape.MemberRefs {
+		renamedName, _ := r.InputFieldRename(r.Ops.Create.Name, memberName)
+		if renamedName == tagField.Names.Original {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTagField adds a Spec field for the resource's tags if the generator
+// config instructs the generator to keep the resource's tags in sync using
+// tagging operations but the Create operation's Input shape has no member
+// containing tags, e.g. an S3 Bucket. The field has the shape of the tags
+// returned by the tags list operation.
+func (r *CRD) AddTagField() {
+	tagsConfig := r.genCfg.ResourceTagsConfig(r.Names.Original)
+	if tagsConfig == nil || r.TagField() != nil {
+		return
+	}
+	fieldName := defaultTagFieldName
+	if tagsConfig.Field != nil {
+		fieldName = *tagsConfig.Field
+	}
+	if _, found := r.SpecFields[fieldName]; found {
+		// TagOps() reports the misconfiguration
+		return
+	}
+	listOp := r.newTagOp(tagsConfig.ListOperation, tagOpTypeList)
+	tagsRef := listOp.Operation.OutputRef.Shape.MemberRefs[listOp.TagsMember]
+	r.AddSpecField(names.New(fieldName), tagsRef)
+}
+
+type tagOpType int
+
+const (
+	tagOpTypeList tagOpType = iota
+	tagOpTypeTag
+	tagOpTypeUntag
+)
+
+// newTagOp returns a TagOp describing the supplied tagging operation. The
+// members containing the resource's ARN and the tags or tag keys are
+// identified by their shapes.
+func (r *CRD) newTagOp(opName string, opType tagOpType) *TagOp {
+	op, found := r.sdkAPI.API.Operations[opName]
+	if !found {
+		msg := fmt.Sprintf(
+			"unknown tagging operation %s configured for resource %s",
+			opName, r.Names.Original,
+		)
+		panic(msg)
+	}
+	tagOp := &TagOp{Operation: op}
+	inShape := op.InputRef.Shape
+	for _, memberName := range inShape.MemberNames() {
+		memberShape := inShape.MemberRefs[memberName].Shape
+		if memberShape.Type == "string" && inShape.IsRequired(memberName) {
+			tagOp.IdentifierMember = memberName
+			tagOp.IdentifierIsName = !strings.Contains(
+				strings.ToLower(memberName), "arn",
+			)
+			break
+		}
+	}
+	// The tags are in the Output shape of the operation that lists a
+	// resource's tags and in the Input shape of the other operations
+	tagsShape := inShape
+	if opType == tagOpTypeList {
+		tagsShape = op.OutputRef.Shape
+	}
+	for _, memberName := range tagsShape.MemberNames() {
+		memberShape := tagsShape.MemberRefs[memberName].Shape
+		if opType == tagOpTypeUntag {
+			if memberShape.Type == "list" &&
+				memberShape.MemberRef.Shape.Type == "string" {
+				tagOp.TagsMember = memberName
+				break
+			}
+			continue
+		}
+		if isTagsShape(memberShape) {
+			tagOp.setTagsMember(memberName, memberShape)
+			break
+		}
+	}
+	if tagOp.TagsMember == "" && opType == tagOpTypeTag {
+		// Some Tag operations take the tags in a structure, e.g. the
+		// Tagging member of S3's PutBucketTagging
+		for _, memberName := range tagsShape.MemberNames() {
+			memberShape := tagsShape.MemberRefs[memberName].Shape
+			if memberShape.Type != "structure" {
+				continue
+			}
+			for _, innerName := range memberShape.MemberNames() {
+				innerShape := memberShape.MemberRefs[innerName].Shape
+				if isTagsShape(innerShape) {
+					tagOp.setTagsMember(innerName, innerShape)
+					tagOp.TagsContainerMember = memberName
+					tagOp.TagsContainerShapeName = memberShape.ShapeName
+					break
+				}
+			}
+			if tagOp.TagsMember != "" {
+				break
+			}
+		}
+	}
+	// An Untag operation without a member containing tag keys removes all of
+	// the resource's tags, e.g. S3's DeleteBucketTagging
+	if tagOp.IdentifierMember == "" ||
+		(tagOp.TagsMember == "" && opType != tagOpTypeUntag) {
+		msg := fmt.Sprintf(
+			"unable to find resource identifier and tags members for tagging operation %s",
+			opName,
+		)
+		panic(msg)
+	}
+	return tagOp
+}
+
+// setTagsMember sets the supplied member as the member containing the tags
+func (op *TagOp) setTagsMember(
+	memberName string,
+	memberShape *awssdkmodel.Shape,
+) {
+	op.TagsMember = memberName
+	op.TagsIsMap = memberShape.Type == "map"
+	if !op.TagsIsMap {
+		op.TagShapeName = memberShape.MemberRef.Shape.ShapeName
+	}
+}
+
+// isTagsShape returns true if the supplied shape is either a map of strings or
+// a list of structs with Key and Value members
+func isTagsShape(shape *awssdkmodel.Shape) bool {
+	switch shape.Type {
+	case "map":
+		return shape.ValueRef.Shape.Type == "string"
+	case "list":
+		elemShape := shape.MemberRef.Shape
+		if elemShape.Type != "structure" {
+			return false
+		}
+		_, hasKey := elemShape.MemberRefs["Key"]
+		_, hasValue := elemShape.MemberRefs["Value"]
+		return hasKey && hasValue
+	}
+	return false
+}
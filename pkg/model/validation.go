@@ -0,0 +1,375 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdkmodel "github.com/aws/aws-sdk-go/private/model/api"
+
+	"github.com/aws/aws-controllers-k8s/pkg/names"
+)
+
+// requiredSpecFieldNames returns a sorted slice of the names of the Spec
+// fields that correspond to required members of the Create Input shape
+func (r *CRD) requiredSpecFieldNames() []string {
+	res := []string{}
+	if r.Ops.Create == nil || r.Ops.Create.InputRef.Shape == nil {
+		return res
+	}
+	for _, memberName := range r.Ops.Create.InputRef.Shape.Required {
+		renamedName, _ := r.InputFieldRename(r.Ops.Create.Name, memberName)
+		if _, found := r.SpecFields[renamedName]; found {
+			res = append(res, renamedName)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// shapeConstraints returns the min, max and pattern constraints for the
+// supplied shape
+func (r *CRD) shapeConstraints(shape *awssdkmodel.Shape) *ShapeConstraints {
+	shapeName := shape.OrigShapeName
+	if shapeName == "" {
+		shapeName = shape.ShapeName
+	}
+	return r.sdkAPI.GetShapeConstraints(shapeName)
+}
+
+// shapePattern returns the pattern that the whole value of the supplied
+// string shape must match, or the empty string if the shape has no pattern.
+// Some of the patterns declared by API models use syntax that Go's regexp
+// package does not support (lookaheads, for example), and those are skipped.
+func (r *CRD) shapePattern(shape *awssdkmodel.Shape) string {
+	constraints := r.shapeConstraints(shape)
+	if shape.Type != "string" || constraints == nil || constraints.Pattern == "" {
+		return ""
+	}
+	pattern := "^(?:" + constraints.Pattern + ")$"
+	if _, err := regexp.Compile(pattern); err != nil {
+		return ""
+	}
+	return pattern
+}
+
+// specPatternVarName returns the name of the package-level variable holding
+// the compiled pattern of the supplied Spec field, e.g.
+// "specPatternRepositoryName"
+func (r *CRD) specPatternVarName(fieldName string) string {
+	return "specPattern" + r.SpecFields[fieldName].Names.Camel
+}
+
+// GoCodeValidationPatterns returns the Go code declaring the package-level
+// variables holding the compiled patterns used by the code returned by
+// GoCodeValidateSpec, so that the patterns are compiled once instead of on
+// every validation. It's meant to go in a `var` block.
+//
+// Sample output:
+//
+//	specPatternRepositoryName = regexp.MustCompile("^(?:(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)*[a-z0-9]+(?:[._-][a-z0-9]+)*)$")
+func (r *CRD) GoCodeValidationPatterns(
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	for _, fieldName := range r.SpecFieldNames() {
+		specField := r.SpecFields[fieldName]
+		if specField.ShapeRef == nil || specField.ShapeRef.Shape == nil {
+			continue
+		}
+		pattern := r.shapePattern(specField.ShapeRef.Shape)
+		if pattern == "" {
+			continue
+		}
+		out += fmt.Sprintf(
+			"%s%s = regexp.MustCompile(%s)\n",
+			indent, r.specPatternVarName(fieldName), strconv.Quote(pattern),
+		)
+	}
+	return out
+}
+
+// GoCodeValidateSpec returns the Go code that validates the values of the
+// CRD's Spec fields against the constraints that the API model declares for
+// the corresponding members of the Create Input shape: required members,
+// enumerated values, minimum and maximum lengths or values, patterns and
+// Amazon Resource Names (ARNs). Only top-level Spec fields are validated.
+//
+// Sample output:
+//
+//	if ko.Spec.RepositoryName == nil {
+//		errs = append(errs, field.Required(field.NewPath("spec", "repositoryName"), ""))
+//	}
+//	if ko.Spec.RepositoryName != nil {
+//		if len(*ko.Spec.RepositoryName) < 2 {
+//			errs = append(errs, field.Invalid(field.NewPath("spec", "repositoryName"), *ko.Spec.RepositoryName, "must be at least 2 characters long"))
+//		}
+//	}
+func (r *CRD) GoCodeValidateSpec(
+	// String representing the name of the variable containing the CR
+	koVarName string,
+	// String representing the name of the `field.ErrorList` variable that
+	// validation errors are appended to
+	errsVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	required := r.requiredSpecFieldNames()
+
+	for _, fieldName := range r.SpecFieldNames() {
+		specField := r.SpecFields[fieldName]
+		varName := koVarName + ".Spec." + specField.Names.Camel
+		pathCode := fmt.Sprintf(
+			"field.NewPath(\"spec\", \"%s\")", specField.Names.CamelLower,
+		)
+		for _, req := range required {
+			if req == fieldName {
+				out += fmt.Sprintf("%sif %s == nil {\n", indent, varName)
+				out += fmt.Sprintf(
+					"%s\t%s = append(%s, field.Required(%s, \"\"))\n",
+					indent, errsVarName, errsVarName, pathCode,
+				)
+				out += fmt.Sprintf("%s}\n", indent)
+			}
+		}
+		if specField.ShapeRef == nil || specField.ShapeRef.Shape == nil {
+			continue
+		}
+		checks := r.goCodeValidateField(
+			fieldName, varName, pathCode, errsVarName,
+			specField.ShapeRef.Shape, indentLevel+1,
+		)
+		if checks == "" {
+			continue
+		}
+		out += fmt.Sprintf("%sif %s != nil {\n", indent, varName)
+		out += checks
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
+
+// goCodeValidateField returns the Go code that validates the value of a
+// single, non-nil Spec field against the constraints of its shape
+func (r *CRD) goCodeValidateField(
+	fieldName string,
+	varName string,
+	pathCode string,
+	errsVarName string,
+	shape *awssdkmodel.Shape,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	appendInvalid := func(cond string, value string, msg string) {
+		out += fmt.Sprintf("%sif %s {\n", indent, cond)
+		out += fmt.Sprintf(
+			"%s\t%s = append(%s, field.Invalid(%s, %s, %q))\n",
+			indent, errsVarName, errsVarName, pathCode, value, msg,
+		)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	constraints := r.shapeConstraints(shape)
+
+	switch shape.Type {
+	case "string":
+		value := "*" + varName
+		if shape.IsEnum() {
+			out += r.goCodeValidateEnum(
+				varName, pathCode, errsVarName, shape, indentLevel,
+			)
+		}
+		if constraints != nil && constraints.Min != nil && *constraints.Min > 0 {
+			min := int64(*constraints.Min)
+			appendInvalid(
+				fmt.Sprintf("len(%s) < %d", value, min), value,
+				fmt.Sprintf("must be at least %d characters long", min),
+			)
+		}
+		if constraints != nil && constraints.Max != nil {
+			max := int64(*constraints.Max)
+			appendInvalid(
+				fmt.Sprintf("len(%s) > %d", value, max), value,
+				fmt.Sprintf("must be no more than %d characters long", max),
+			)
+		}
+		if r.shapePattern(shape) != "" {
+			appendInvalid(
+				fmt.Sprintf(
+					"!%s.MatchString(%s)", r.specPatternVarName(fieldName), value,
+				),
+				value,
+				fmt.Sprintf("must match the pattern %s", constraints.Pattern),
+			)
+		}
+		if isARNFieldName(fieldName) {
+			appendInvalid(
+				fmt.Sprintf("!arn.IsARN(%s)", value), value,
+				"must be a valid Amazon Resource Name (ARN)",
+			)
+		}
+	case "integer", "long", "float", "double":
+		value := "*" + varName
+		formatNumber := func(val float64) string {
+			if shape.Type == "float" || shape.Type == "double" {
+				return strconv.FormatFloat(val, 'g', -1, 64)
+			}
+			return strconv.FormatInt(int64(val), 10)
+		}
+		if constraints != nil && constraints.Min != nil {
+			min := formatNumber(*constraints.Min)
+			appendInvalid(
+				fmt.Sprintf("%s < %s", value, min), value,
+				fmt.Sprintf("must be greater than or equal to %s", min),
+			)
+		}
+		if constraints != nil && constraints.Max != nil {
+			max := formatNumber(*constraints.Max)
+			appendInvalid(
+				fmt.Sprintf("%s > %s", value, max), value,
+				fmt.Sprintf("must be less than or equal to %s", max),
+			)
+		}
+	case "list", "map":
+		value := varName
+		if constraints != nil && constraints.Min != nil && *constraints.Min > 0 {
+			min := int64(*constraints.Min)
+			appendInvalid(
+				fmt.Sprintf("len(%s) < %d", value, min), fmt.Sprintf("len(%s)", value),
+				fmt.Sprintf("must have at least %d items", min),
+			)
+		}
+		if constraints != nil && constraints.Max != nil {
+			max := int64(*constraints.Max)
+			appendInvalid(
+				fmt.Sprintf("len(%s) > %d", value, max), fmt.Sprintf("len(%s)", value),
+				fmt.Sprintf("must have no more than %d items", max),
+			)
+		}
+	}
+	return out
+}
+
+// goCodeValidateEnum returns the Go code that checks that the value of a
+// string Spec field is one of the values of the field's enumerated type
+//
+// Sample output:
+//
+//	switch *ko.Spec.ImageTagMutability {
+//	case string(svcapitypes.ImageTagMutability_MUTABLE),
+//		string(svcapitypes.ImageTagMutability_IMMUTABLE):
+//	default:
+//		errs = append(errs, field.NotSupported(field.NewPath("spec", "imageTagMutability"), *ko.Spec.ImageTagMutability, []string{"MUTABLE", "IMMUTABLE"}))
+//	}
+func (r *CRD) goCodeValidateEnum(
+	varName string,
+	pathCode string,
+	errsVarName string,
+	shape *awssdkmodel.Shape,
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	enumNames := names.New(shape.ShapeName)
+	if r.sdkAPI.HasConflictingTypeName(shape.ShapeName, r.genCfg) {
+		enumNames.Camel += ConflictingNameSuffix
+	}
+	cases := []string{}
+	quoted := []string{}
+	for _, val := range shape.Enum {
+		enumVal := newEnumVal(val)
+		cases = append(cases, fmt.Sprintf(
+			"string(svcapitypes.%s_%s)", enumNames.Camel, enumVal.Clean,
+		))
+		quoted = append(quoted, strconv.Quote(val))
+	}
+	out += fmt.Sprintf("%sswitch *%s {\n", indent, varName)
+	out += fmt.Sprintf(
+		"%scase %s:\n", indent, strings.Join(cases, ",\n"+indent+"\t"),
+	)
+	out += fmt.Sprintf("%sdefault:\n", indent)
+	out += fmt.Sprintf(
+		"%s\t%s = append(%s, field.NotSupported(%s, *%s, []string{%s}))\n",
+		indent, errsVarName, errsVarName, pathCode, varName,
+		strings.Join(quoted, ", "),
+	)
+	out += fmt.Sprintf("%s}\n", indent)
+	return out
+}
+
+// GoCodeDefaultSpec returns the Go code that sets the default values, from
+// the `default_values` generator config for the resource, on CRD Spec fields
+// that have no value.
+//
+// Sample output:
+//
+//	if ko.Spec.ImageTagMutability == nil {
+//		ko.Spec.ImageTagMutability = aws.String("MUTABLE")
+//	}
+func (r *CRD) GoCodeDefaultSpec(
+	// String representing the name of the variable containing the CR
+	koVarName string,
+	// Number of levels of indentation to use
+	indentLevel int,
+) string {
+	out := ""
+	indent := strings.Repeat("\t", indentLevel)
+	defaults := r.genCfg.ResourceDefaultValues(r.Names.Original)
+	fieldNames := make([]string, 0, len(defaults))
+	for fieldName := range defaults {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		specField, found := r.SpecFields[fieldName]
+		if !found {
+			panic("default value configured for unknown Spec field " + fieldName)
+		}
+		value := defaults[fieldName]
+		var setTo string
+		switch specField.GoType {
+		case "*string":
+			setTo = fmt.Sprintf("aws.String(%s)", strconv.Quote(value))
+		case "*bool":
+			setTo = fmt.Sprintf("aws.Bool(%s)", value)
+		case "*int64":
+			setTo = fmt.Sprintf("aws.Int64(%s)", value)
+		case "*float64":
+			setTo = fmt.Sprintf("aws.Float64(%s)", value)
+		default:
+			panic("default value configured for unsupported Spec field type " + specField.GoType)
+		}
+		varName := koVarName + ".Spec." + specField.Names.Camel
+		out += fmt.Sprintf("%sif %s == nil {\n", indent, varName)
+		out += fmt.Sprintf("%s\t%s = %s\n", indent, varName, setTo)
+		out += fmt.Sprintf("%s}\n", indent)
+	}
+	return out
+}
+
+// isARNFieldName returns true if the supplied field name indicates that the
+// field contains an Amazon Resource Name (ARN)
+func isARNFieldName(fieldName string) bool {
+	return strings.HasSuffix(fieldName, "Arn") ||
+		strings.HasSuffix(fieldName, "ARN")
+}
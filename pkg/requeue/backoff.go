@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultBackoff is a backoff suitable for polling a backend AWS service API
+// resource that is transitioning between states: an exponential backoff
+// starting at 5 seconds, with 10% jitter and capped at 2 minutes
+var DefaultBackoff = Capped(
+	WithJitter(Exponential(5*time.Second, 2), 0.1),
+	2*time.Minute,
+)
+
+// Backoff is a policy that decides how long to wait before retrying
+type Backoff interface {
+	// Delay returns how long to wait before the supplied attempt. Attempts
+	// are numbered from 1.
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFunc is a function that implements Backoff
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay implements Backoff
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// Constant returns a Backoff that waits the supplied duration before every
+// attempt
+func Constant(delay time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		return delay
+	})
+}
+
+// Exponential returns a Backoff that waits the supplied base duration before
+// the first attempt, and multiplies the delay by the supplied factor for each
+// following attempt
+func Exponential(base time.Duration, factor float64) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		if attempt < 1 {
+			attempt = 1
+		}
+		delay := float64(base) * math.Pow(factor, float64(attempt-1))
+		if delay > math.MaxInt64 {
+			return time.Duration(math.MaxInt64)
+		}
+		return time.Duration(delay)
+	})
+}
+
+// WithJitter returns a Backoff that randomly varies the delays of the supplied
+// Backoff by up to the supplied fraction of the delay, e.g. 0.1 for +/-10%,
+// so that resources that started waiting together are retried at different
+// times
+func WithJitter(b Backoff, fraction float64) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		delay := float64(b.Delay(attempt))
+		jitter := delay * fraction * (2*rand.Float64() - 1)
+		return time.Duration(delay + jitter)
+	})
+}
+
+// Capped returns a Backoff that never waits longer than the supplied maximum
+// delay
+func Capped(b Backoff, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		if delay := b.Delay(attempt); delay < max {
+			return delay
+		}
+		return max
+	})
+}
+
+// NeededWithBackoff returns a new RequeueNeededWithBackoff to instruct the
+// ACK runtime to requeue the processing item after a delay computed by the
+// supplied Backoff from the number of consecutive times the item has been
+// requeued this way.
+func NeededWithBackoff(
+	err error,
+	backoff Backoff,
+) *RequeueNeededWithBackoff {
+	return &RequeueNeededWithBackoff{
+		RequeueNeeded{
+			err: err,
+		},
+		backoff,
+	}
+}
+
+// An error to instruct the ACK runtime to requeue the processing item after a
+// delay computed by a Backoff without been logged as error. This should be
+// used when polling for a condition that may take a while to be fulfilled,
+// e.g. a resource transitioning between states.
+type RequeueNeededWithBackoff struct {
+	RequeueNeeded
+	backoff Backoff
+}
+
+func (e *RequeueNeededWithBackoff) Error() string {
+	if e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+// Backoff returns the Backoff computing the delay before the item is
+// processed again
+func (e *RequeueNeededWithBackoff) Backoff() Backoff {
+	return e.backoff
+}
+
+func (e *RequeueNeededWithBackoff) Unwrap() error {
+	return e.err
+}
+
+// Ensure RequeueNeededWithBackoff implements the error interface
+var _ error = &RequeueNeededWithBackoff{}
+
+// Attempts counts the consecutive attempts to process each item, keyed by an
+// item identifier, e.g. the namespaced name of a CR. It's safe for concurrent
+// use.
+type Attempts struct {
+	sync.Mutex
+	counts map[string]int
+}
+
+// NewAttempts returns a new, empty Attempts
+func NewAttempts() *Attempts {
+	return &Attempts{counts: map[string]int{}}
+}
+
+// Next records a new attempt for the supplied item and returns its number.
+// Attempts are numbered from 1.
+func (a *Attempts) Next(key string) int {
+	a.Lock()
+	defer a.Unlock()
+	a.counts[key]++
+	return a.counts[key]
+}
+
+// Reset forgets the attempts for the supplied item, e.g. because it was
+// processed successfully
+func (a *Attempts) Reset(key string) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.counts, key)
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
+)
+
+func TestBackoff(t *testing.T) {
+	constant := requeue.Constant(3 * time.Second)
+	assert.Equal(t, 3*time.Second, constant.Delay(1))
+	assert.Equal(t, 3*time.Second, constant.Delay(10))
+
+	exp := requeue.Exponential(time.Second, 2)
+	assert.Equal(t, time.Second, exp.Delay(1))
+	assert.Equal(t, 2*time.Second, exp.Delay(2))
+	assert.Equal(t, 8*time.Second, exp.Delay(4))
+
+	capped := requeue.Capped(exp, 5*time.Second)
+	assert.Equal(t, 4*time.Second, capped.Delay(3))
+	assert.Equal(t, 5*time.Second, capped.Delay(4))
+	assert.Equal(t, 5*time.Second, capped.Delay(100))
+
+	jitter := requeue.WithJitter(constant, 0.1)
+	for i := 0; i < 100; i++ {
+		delay := jitter.Delay(1)
+		assert.True(t, delay >= 2700*time.Millisecond, delay)
+		assert.True(t, delay <= 3300*time.Millisecond, delay)
+	}
+
+	assert.True(t, requeue.DefaultBackoff.Delay(100) <= 2*time.Minute)
+}
+
+func TestAttempts(t *testing.T) {
+	attempts := requeue.NewAttempts()
+	assert.Equal(t, 1, attempts.Next("ns/a"))
+	assert.Equal(t, 2, attempts.Next("ns/a"))
+	assert.Equal(t, 1, attempts.Next("ns/b"))
+	attempts.Reset("ns/a")
+	assert.Equal(t, 1, attempts.Next("ns/a"))
+}
+
+func TestRequeueNeededWithBackoff(t *testing.T) {
+	backoff := requeue.Constant(time.Second)
+	got := requeue.NeededWithBackoff(errors.New("some error"), backoff)
+	assert.Equal(t, "some error", got.Error())
+	assert.EqualError(t, got.Unwrap(), "some error")
+	assert.Equal(t, time.Second, got.Backoff().Delay(1))
+
+	var target *requeue.RequeueNeededWithBackoff
+	assert.True(t, errors.As(errors.Wrap(got, "wrapped"), &target))
+}
+
+func TestRetryAfter(t *testing.T) {
+	newRequest := func(retryAfter string) *request.Request {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+		return &request.Request{
+			Error: awserr.NewRequestFailure(
+				awserr.New("ThrottlingException", "slow down", nil),
+				http.StatusTooManyRequests, "request-id",
+			),
+			HTTPResponse: &http.Response{Header: header},
+		}
+	}
+
+	r := newRequest("7")
+	requeue.RetryAfterHandler.Fn(r)
+	delay, ok := requeue.RetryAfter(r.Error)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, delay)
+	// The error is still an AWS request failure
+	awsErr, ok := r.Error.(awserr.RequestFailure)
+	assert.True(t, ok)
+	assert.Equal(t, "ThrottlingException", awsErr.Code())
+	assert.Equal(t, http.StatusTooManyRequests, awsErr.StatusCode())
+
+	r = newRequest(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	requeue.RetryAfterHandler.Fn(r)
+	delay, ok = requeue.RetryAfter(errors.Wrap(r.Error, "wrapped"))
+	assert.True(t, ok)
+	assert.True(t, delay > 50*time.Second && delay <= time.Minute, delay)
+
+	r = newRequest("")
+	requeue.RetryAfterHandler.Fn(r)
+	_, ok = requeue.RetryAfter(r.Error)
+	assert.False(t, ok)
+
+	r = newRequest("soon")
+	requeue.RetryAfterHandler.Fn(r)
+	_, ok = requeue.RetryAfter(r.Error)
+	assert.False(t, ok)
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package requeue
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+)
+
+// RetryAfterHandler is an aws-sdk-go request handler that keeps the delay in
+// the Retry-After header of a failed AWS service API response with the
+// request's error, so that RetryAfter can return it. It should be added to
+// the Complete handlers of a session.
+var RetryAfterHandler = request.NamedHandler{
+	Name: "ack.requeue.RetryAfterHandler",
+	Fn: func(r *request.Request) {
+		if r.Error == nil || r.HTTPResponse == nil {
+			return
+		}
+		reqErr, ok := r.Error.(awserr.RequestFailure)
+		if !ok {
+			return
+		}
+		delay, ok := parseRetryAfter(
+			r.HTTPResponse.Header.Get("Retry-After"), time.Now(),
+		)
+		if !ok {
+			return
+		}
+		r.Error = &retryAfterError{reqErr, delay}
+	},
+}
+
+// retryAfterError is an AWS service API request failure with the delay from
+// the response's Retry-After header. It still implements awserr.Error and
+// awserr.RequestFailure, so code inspecting the error code or status code
+// keeps working.
+type retryAfterError struct {
+	awserr.RequestFailure
+	retryAfter time.Duration
+}
+
+// RetryAfter returns how long the AWS service API asked to wait before
+// retrying the request
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// RetryAfter returns the delay an AWS service API asked to wait before
+// retrying the request that failed with the supplied error, if any
+func RetryAfter(err error) (time.Duration, bool) {
+	var hint interface{ RetryAfter() time.Duration }
+	if errors.As(err, &hint) {
+		return hint.RetryAfter(), true
+	}
+	return 0, false
+}
+
+// parseRetryAfter returns the delay in the supplied value of a Retry-After
+// header, which is either a number of seconds or an HTTP date, relative to
+// the supplied time
+func parseRetryAfter(val string, now time.Time) (time.Duration, bool) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(val); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	at, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	delay := at.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
@@ -0,0 +1,368 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// AuditLogStdout is the value of the --audit-log flag that writes the
+	// audit log to the standard output
+	AuditLogStdout = "stdout"
+	// AuditResultSucceeded is the result of an audited AWS service API call
+	// that succeeded
+	AuditResultSucceeded = "succeeded"
+	// AuditResultFailed is the result of an audited AWS service API call that
+	// failed
+	AuditResultFailed = "failed"
+	// redactedValue replaces the values of sensitive request parameters in
+	// audit records
+	redactedValue = "*** REDACTED ***"
+)
+
+// readOperationNamePrefixes contains the prefixes of the names of AWS service
+// API operations that only read resources, e.g. "DescribeRepositories". The
+// resource managers call them to look up the state of resources while they
+// create, update or delete them, and they aren't audited.
+var readOperationNamePrefixes = []string{
+	"Describe",
+	"Get",
+	"List",
+	"Head",
+	"BatchGet",
+}
+
+// sensitiveFieldNameParts contains lowercased parts of the names of request
+// parameters whose values are redacted from audit records, in addition to the
+// parameters that the API models mark as sensitive
+var sensitiveFieldNameParts = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"authtoken",
+	"sessiontoken",
+	"privatekey",
+	"credential",
+}
+
+// AuditRecord describes a call to an AWS service API that the service
+// controller made to create, update or delete the backend AWS service API
+// resource for a CR
+type AuditRecord struct {
+	// Time is when the AWS service API call completed
+	Time time.Time `json:"time"`
+	// Namespace is the namespace of the CR
+	Namespace string `json:"namespace"`
+	// Name is the name of the CR
+	Name string `json:"name"`
+	// UID is the UID of the CR
+	UID string `json:"uid"`
+	// GroupKind is the API group and kind of the CR
+	GroupKind string `json:"groupKind"`
+	// AccountID is the AWS account that owns the resource
+	AccountID string `json:"accountID"`
+	// Region is the AWS region of the resource
+	Region string `json:"region"`
+	// Action is the resource manager method that made the AWS service API
+	// call: Create, Update or Delete
+	Action string `json:"action"`
+	// Operation is the name of the AWS service API operation that was
+	// called, e.g. "CreateRepository"
+	Operation string `json:"operation"`
+	// Parameters contains the request parameters, with the values of
+	// sensitive parameters redacted
+	Parameters interface{} `json:"parameters,omitempty"`
+	// RequestID is the identifier of the AWS service API request
+	RequestID string `json:"requestID,omitempty"`
+	// Result is either "succeeded" or "failed"
+	Result string `json:"result"`
+	// Error is the error returned by the AWS service API call, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink durably records the calls that the service controller makes to
+// AWS service APIs to create, update and delete resources
+type AuditSink interface {
+	// Record writes the supplied AuditRecord to the audit log
+	Record(*AuditRecord) error
+}
+
+// jsonLinesAuditSink is an AuditSink that writes each AuditRecord as a line of
+// JSON
+type jsonLinesAuditSink struct {
+	sync.Mutex
+	w io.Writer
+	// file is the file the audit records are written to, if any. It is
+	// synced after each record is written.
+	file *os.File
+}
+
+// Record implements `AuditSink.Record`
+func (s *jsonLinesAuditSink) Record(rec *AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	if _, err = s.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if s.file != nil {
+		return s.file.Sync()
+	}
+	return nil
+}
+
+// NewStdoutAuditSink returns an AuditSink that writes audit records to the
+// standard output as lines of JSON
+func NewStdoutAuditSink() AuditSink {
+	return &jsonLinesAuditSink{w: os.Stdout}
+}
+
+// NewFileAuditSink returns an AuditSink that appends audit records to the file
+// at the supplied path as lines of JSON. The file is created if it doesn't
+// exist.
+func NewFileAuditSink(path string) (AuditSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonLinesAuditSink{w: f, file: f}, nil
+}
+
+// newAuditSink returns the AuditSink writing to the supplied destination,
+// which is either "stdout" or the path of a file, or nil if the destination
+// is empty and AWS service API calls aren't audited
+func newAuditSink(dest string) (AuditSink, error) {
+	switch dest {
+	case "":
+		return nil, nil
+	case AuditLogStdout:
+		return NewStdoutAuditSink(), nil
+	}
+	return NewFileAuditSink(dest)
+}
+
+// auditContextKey is the key of the auditInfo in the context of AWS service
+// API calls
+type auditContextKey struct{}
+
+// auditInfo contains the information about the resource that is included in
+// the audit records of the AWS service API calls made to manage it
+type auditInfo struct {
+	res       acktypes.AWSResource
+	groupKind string
+	accountID ackv1alpha1.AWSAccountID
+	region    ackv1alpha1.AWSRegion
+	// action is the resource manager method being called. AWS service API
+	// calls are only audited when it's set.
+	action string
+}
+
+// withAuditInfo returns a context containing the information about the
+// supplied resource that is included in audit records
+func (r *reconciler) withAuditInfo(
+	ctx context.Context,
+	res acktypes.AWSResource,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, &auditInfo{
+		res:       res,
+		groupKind: r.rd.GroupKind().String(),
+		accountID: acctID,
+		region:    region,
+	})
+}
+
+// withAuditAction returns a context in which the AWS service API calls made
+// by the supplied resource manager method, e.g. "Create", are audited
+func withAuditAction(ctx context.Context, action string) context.Context {
+	info, ok := ctx.Value(auditContextKey{}).(*auditInfo)
+	if !ok {
+		return ctx
+	}
+	actionInfo := *info
+	actionInfo.action = action
+	return context.WithValue(ctx, auditContextKey{}, &actionInfo)
+}
+
+// auditHandler returns an aws-sdk-go request handler that records the
+// completed AWS service API calls made by the resource manager's Create,
+// Update and Delete methods in the reconciler's AuditSink. The calls that only
+// read resources aren't recorded.
+func (r *reconciler) auditHandler() request.NamedHandler {
+	return request.NamedHandler{
+		Name: "ack.AuditHandler",
+		Fn: func(req *request.Request) {
+			info, ok := req.Context().Value(auditContextKey{}).(*auditInfo)
+			if !ok || info.action == "" || isReadOperation(req.Operation) {
+				return
+			}
+			mo := info.res.MetaObject()
+			rec := &AuditRecord{
+				Time:       time.Now().UTC(),
+				Namespace:  mo.GetNamespace(),
+				Name:       mo.GetName(),
+				UID:        string(mo.GetUID()),
+				GroupKind:  info.groupKind,
+				AccountID:  string(info.accountID),
+				Region:     string(info.region),
+				Action:     info.action,
+				Operation:  req.Operation.Name,
+				Parameters: RedactParameters(req.Params),
+				RequestID:  req.RequestID,
+				Result:     AuditResultSucceeded,
+			}
+			if req.Error != nil {
+				rec.Result = AuditResultFailed
+				rec.Error = req.Error.Error()
+			}
+			if err := r.audit.Record(rec); err != nil {
+				r.log.Error(
+					err, "failed to write audit record",
+					"operation", rec.Operation,
+				)
+			}
+		},
+	}
+}
+
+// isReadOperation returns true if the supplied AWS service API operation only
+// reads resources, going by its HTTP method for REST APIs and by its name for
+// the other APIs, whose operations are all POST requests
+func isReadOperation(op *request.Operation) bool {
+	if op == nil {
+		return false
+	}
+	switch op.HTTPMethod {
+	case "GET", "HEAD":
+		return true
+	}
+	for _, prefix := range readOperationNamePrefixes {
+		if strings.HasPrefix(op.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactParameters returns a representation of the supplied aws-sdk-go Input
+// shape that can be marshaled to JSON, in which the values of sensitive
+// members are redacted. Members are sensitive if the API model marks them as
+// such or if their names suggest they contain passwords, secrets, tokens or
+// credentials.
+func RedactParameters(params interface{}) interface{} {
+	if params == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(params))
+}
+
+// redactValue returns a representation of the supplied value, which is part
+// of an aws-sdk-go Input shape, in which sensitive members are redacted
+func redactValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			return t
+		}
+		res := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				// unexported field, e.g. the `_ struct{}` of Input shapes
+				continue
+			}
+			fv := v.Field(i)
+			if isNilValue(fv) {
+				continue
+			}
+			if field.Tag.Get("sensitive") == "true" ||
+				isSensitiveName(field.Name) {
+				res[field.Name] = redactedValue
+				continue
+			}
+			res[field.Name] = redactValue(fv)
+		}
+		return res
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Binary blobs, e.g. file contents, are not recorded
+			return redactedValue
+		}
+		res := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res = append(res, redactValue(v.Index(i)))
+		}
+		return res
+	case reflect.Map:
+		res := map[string]interface{}{}
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if isSensitiveName(key) {
+				res[key] = redactedValue
+				continue
+			}
+			res[key] = redactValue(iter.Value())
+		}
+		return res
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// isNilValue returns true if the supplied value is a nil pointer, interface,
+// slice or map
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	}
+	return false
+}
+
+// isSensitiveName returns true if the supplied request parameter name
+// suggests that its value is a password, a secret, a token or a credential
+func isSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveFieldNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
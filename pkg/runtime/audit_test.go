@@ -0,0 +1,126 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/require"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+func TestRedactParameters(t *testing.T) {
+	require := require.New(t)
+
+	params := ackrt.RedactParameters(&elasticache.CreateReplicationGroupInput{
+		AuthToken:          aws.String("hunter2"),
+		ReplicationGroupId: aws.String("my-group"),
+		NumCacheClusters:   aws.Int64(2),
+		Tags: []*elasticache.Tag{
+			{Key: aws.String("team"), Value: aws.String("storage")},
+		},
+	})
+	require.Equal(map[string]interface{}{
+		"AuthToken":          "*** REDACTED ***",
+		"ReplicationGroupId": "my-group",
+		"NumCacheClusters":   int64(2),
+		"Tags": []interface{}{
+			map[string]interface{}{"Key": "team", "Value": "storage"},
+		},
+	}, params)
+
+	params = ackrt.RedactParameters(&sns.CreatePlatformApplicationInput{
+		Name:     aws.String("my-app"),
+		Platform: aws.String("GCM"),
+		Attributes: map[string]*string{
+			"PlatformCredential":   aws.String("api-key"),
+			"EventEndpointCreated": aws.String("arn:aws:sns:us-west-2:123456789012:topic"),
+		},
+	})
+	require.Equal(map[string]interface{}{
+		"Name":     "my-app",
+		"Platform": "GCM",
+		"Attributes": map[string]interface{}{
+			"PlatformCredential":   "*** REDACTED ***",
+			"EventEndpointCreated": "arn:aws:sns:us-west-2:123456789012:topic",
+		},
+	}, params)
+}
+
+func TestIsReadOperation(t *testing.T) {
+	require := require.New(t)
+
+	for _, test := range []struct {
+		op   *request.Operation
+		read bool
+	}{
+		// Query and JSON APIs only have POST operations
+		{&request.Operation{Name: "DescribeReplicationGroups", HTTPMethod: "POST"}, true},
+		{&request.Operation{Name: "ListTagsForResource", HTTPMethod: "POST"}, true},
+		{&request.Operation{Name: "GetTopicAttributes", HTTPMethod: "POST"}, true},
+		{&request.Operation{Name: "ModifyReplicationGroup", HTTPMethod: "POST"}, false},
+		{&request.Operation{Name: "SetTopicAttributes", HTTPMethod: "POST"}, false},
+		// REST APIs read with GET and HEAD requests
+		{&request.Operation{Name: "HeadBucket", HTTPMethod: "HEAD"}, true},
+		{&request.Operation{Name: "GetBucketTagging", HTTPMethod: "GET"}, true},
+		{&request.Operation{Name: "PutBucketTagging", HTTPMethod: "PUT"}, false},
+		{&request.Operation{Name: "DeleteBucket", HTTPMethod: "DELETE"}, false},
+	} {
+		require.Equal(test.read, ackrt.IsReadOperation(test.op), test.op.Name)
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "audit")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	sink, err := ackrt.NewFileAuditSink(path)
+	require.Nil(err)
+	for _, op := range []string{"CreateRepository", "DeleteRepository"} {
+		err = sink.Record(&ackrt.AuditRecord{
+			Namespace: "default",
+			Name:      "my-repo",
+			Operation: op,
+			Result:    ackrt.AuditResultSucceeded,
+		})
+		require.Nil(err)
+	}
+
+	f, err := os.Open(path)
+	require.Nil(err)
+	defer f.Close()
+	ops := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		rec := &ackrt.AuditRecord{}
+		require.Nil(json.Unmarshal(scanner.Bytes(), rec))
+		require.Equal("my-repo", rec.Name)
+		ops = append(ops, rec.Operation)
+	}
+	require.Equal([]string{"CreateRepository", "DeleteRepository"}, ops)
+}
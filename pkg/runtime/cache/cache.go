@@ -43,6 +43,12 @@ func init() {
 	}
 }
 
+// CurrentNamespace returns the namespace in which the current service
+// controller Pod is running
+func CurrentNamespace() string {
+	return currentNamespace
+}
+
 // Caches is used to interact with the different caches
 type Caches struct {
 	// stopCh is a channel use to stop all the
@@ -56,17 +62,21 @@ type Caches struct {
 	Namespaces *NamespaceCache
 }
 
-// New creates a new Caches object from a kubernetes.Interface and
-// a logr.Logger
-func New(clientset kubernetes.Interface, log logr.Logger) Caches {
+// New creates a new Caches object from a kubernetes.Interface, a
+// logr.Logger and a NamespaceFilter selecting the namespaces to cache
+func New(
+	clientset kubernetes.Interface,
+	log logr.Logger,
+	nsFilter NamespaceFilter,
+) Caches {
 	return Caches{
 		Accounts:   NewAccountCache(clientset, log),
-		Namespaces: NewNamespaceCache(clientset, log),
+		Namespaces: NewNamespaceCache(clientset, log, nsFilter),
 	}
 }
 
 // Run runs all the owned caches
-func (c Caches) Run() {
+func (c *Caches) Run() {
 	stopCh := make(chan struct{})
 	if c.Accounts != nil {
 		c.Accounts.Run(stopCh)
@@ -79,6 +89,21 @@ func (c Caches) Run() {
 
 // Stop closes the stop channel and cause all the SharedInformers
 // by caches to stop running
-func (c Caches) Stop() {
+func (c *Caches) Stop() {
 	close(c.stopCh)
 }
+
+// Start implements `manager.Runnable`. It runs all the owned caches until
+// the supplied stop channel is closed.
+func (c *Caches) Start(stopCh <-chan struct{}) error {
+	c.Run()
+	<-stopCh
+	c.Stop()
+	return nil
+}
+
+// NeedLeaderElection implements `manager.LeaderElectionRunnable`. The caches
+// run whether or not the service controller is the leader.
+func (c *Caches) NeedLeaderElection() bool {
+	return false
+}
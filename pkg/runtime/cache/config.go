@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	informersv1 "k8s.io/client-go/informers/core/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	k8scache "k8s.io/client-go/tools/cache"
+)
+
+// ConfigCache watches the ConfigMap containing the service controller's
+// configuration and calls a function with the ConfigMap's data whenever the
+// ConfigMap is created, updated or deleted.
+type ConfigCache struct {
+	log logr.Logger
+
+	// ConfigMap informer
+	informer k8scache.SharedInformer
+	onChange func(data map[string]string)
+}
+
+// NewConfigCache makes a new ConfigCache watching the ConfigMap with the
+// supplied namespace and name from a client.Interface and a logr.Logger. The
+// supplied function is called with the ConfigMap's data, or with an empty map
+// when the ConfigMap is deleted.
+func NewConfigCache(
+	clientset kubernetes.Interface,
+	namespace string,
+	name string,
+	log logr.Logger,
+	onChange func(data map[string]string),
+) *ConfigCache {
+	sharedInformer := informersv1.NewFilteredConfigMapInformer(
+		clientset,
+		namespace,
+		informerResyncPeriod,
+		k8scache.Indexers{},
+		func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector(
+				"metadata.name", name,
+			).String()
+		},
+	)
+	return &ConfigCache{
+		informer: sharedInformer,
+		log:      log.WithName("ConfigCache"),
+		onChange: onChange,
+	}
+}
+
+// Run adds the default event handler functions to the SharedInformer and
+// runs the informer to begin processing items.
+func (c *ConfigCache) Run(stopCh <-chan struct{}) {
+	c.informer.AddEventHandler(k8scache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			object, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				return
+			}
+			c.log.V(1).Info("configuration configmap has been created")
+			c.onChange(object.DeepCopy().Data)
+		},
+		UpdateFunc: func(orig, desired interface{}) {
+			object, ok := desired.(*corev1.ConfigMap)
+			if !ok {
+				return
+			}
+			c.log.V(1).Info("configuration configmap has been updated")
+			c.onChange(object.DeepCopy().Data)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.log.V(1).Info("configuration configmap has been deleted")
+			c.onChange(map[string]string{})
+		},
+	})
+	go c.informer.Run(stopCh)
+}
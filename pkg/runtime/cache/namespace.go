@@ -14,10 +14,12 @@
 package cache
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	informersv1 "k8s.io/client-go/informers/core/v1"
 	kubernetes "k8s.io/client-go/kubernetes"
 	k8scache "k8s.io/client-go/tools/cache"
@@ -31,6 +33,16 @@ type namespaceInfo struct {
 	defaultRegion string
 	// services.k8s.aws/owner-account-id Annotation
 	ownerAccountID string
+	// services.k8s.aws/default-resource-tags Annotation
+	defaultResourceTags string
+	// services.k8s.aws/endpoint-url Annotation
+	endpointURL string
+	// services.k8s.aws/credentials-provider Annotation
+	credentialsProvider string
+	// services.k8s.aws/reconcile-paused Annotation
+	reconcilePaused bool
+	// services.k8s.aws/drift-policy Annotation
+	driftPolicy string
 }
 
 // getDefaultRegion returns the default region value
@@ -49,6 +61,88 @@ func (n *namespaceInfo) getOwnerAccountID() string {
 	return n.ownerAccountID
 }
 
+// getDefaultResourceTags returns the namespace default resource tags
+func (n *namespaceInfo) getDefaultResourceTags() string {
+	if n == nil {
+		return ""
+	}
+	return n.defaultResourceTags
+}
+
+// getEndpointURL returns the namespace AWS service API endpoint URL
+func (n *namespaceInfo) getEndpointURL() string {
+	if n == nil {
+		return ""
+	}
+	return n.endpointURL
+}
+
+// getCredentialsProvider returns the namespace AWS credentials provider
+func (n *namespaceInfo) getCredentialsProvider() string {
+	if n == nil {
+		return ""
+	}
+	return n.credentialsProvider
+}
+
+// isReconcilePaused returns whether reconciliation is paused in the namespace
+func (n *namespaceInfo) isReconcilePaused() bool {
+	if n == nil {
+		return false
+	}
+	return n.reconcilePaused
+}
+
+// getDriftPolicy returns the namespace drift policy
+func (n *namespaceInfo) getDriftPolicy() string {
+	if n == nil {
+		return ""
+	}
+	return n.driftPolicy
+}
+
+// DefaultIgnoredNamespaces returns the names of the namespaces that are
+// ignored by default: the namespace the service controller runs in,
+// 'kube-system' and 'kube-public'
+func DefaultIgnoredNamespaces() []string {
+	return []string{currentNamespace, "kube-system", "kube-public"}
+}
+
+// NamespaceFilter selects the namespaces whose annotations are cached and
+// whose CRs are reconciled
+type NamespaceFilter struct {
+	// IgnoredNamespaces contains the names of namespaces that are never
+	// selected
+	IgnoredNamespaces []string
+	// Selector selects namespaces by their labels. A nil Selector selects
+	// all namespaces.
+	Selector labels.Selector
+}
+
+// isIgnored returns true if the namespace with the supplied name is one of
+// the ignored namespaces
+func (f NamespaceFilter) isIgnored(name string) bool {
+	for _, ignored := range f.IgnoredNamespaces {
+		if name == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSelector returns true if the filter selects namespaces by their labels
+func (f NamespaceFilter) hasSelector() bool {
+	return f.Selector != nil && !f.Selector.Empty()
+}
+
+// Matches returns true if the supplied namespace is selected by the filter
+func (f NamespaceFilter) Matches(ns *corev1.Namespace) bool {
+	if f.isIgnored(ns.ObjectMeta.Name) {
+		return false
+	}
+	return !f.hasSelector() || f.Selector.Matches(labels.Set(ns.ObjectMeta.Labels))
+}
+
 // NamespaceCache is reponsible of keeping track of namespaces
 // annotations, and caching those related to the ACK controller.
 type NamespaceCache struct {
@@ -57,13 +151,20 @@ type NamespaceCache struct {
 	log logr.Logger
 	// Namespace informer
 	informer k8scache.SharedInformer
+	// filter selects the namespaces that are cached
+	filter NamespaceFilter
 	// namespaceInfos maps namespaces names to their known namespaceInfo
 	namespaceInfos map[string]*namespaceInfo
 }
 
 // NewNamespaceCache makes a new NamespaceCache from a
-// kubernetes.Interface and a logr.Logger
-func NewNamespaceCache(clientset kubernetes.Interface, log logr.Logger) *NamespaceCache {
+// kubernetes.Interface, a logr.Logger and a NamespaceFilter selecting the
+// namespaces to cache
+func NewNamespaceCache(
+	clientset kubernetes.Interface,
+	log logr.Logger,
+	filter NamespaceFilter,
+) *NamespaceCache {
 	sharedInformer := informersv1.NewNamespaceInformer(
 		clientset,
 		informerResyncPeriod,
@@ -71,19 +172,17 @@ func NewNamespaceCache(clientset kubernetes.Interface, log logr.Logger) *Namespa
 	)
 	return &NamespaceCache{
 		informer:       sharedInformer,
+		filter:         filter,
 		log:            log.WithName("NamespaceCache"),
 		namespaceInfos: make(map[string]*namespaceInfo),
 	}
 }
 
-// isIgnoredNamespace returns true if an object is of type corev1.Namespace
-// and it metadata name is one of 'ack-system', 'kube-system' or 'kube-public'
-func isIgnoredNamespace(raw interface{}) bool {
+// isSelectedNamespace returns true if an object is of type corev1.Namespace
+// and it is selected by the cache's NamespaceFilter
+func (c *NamespaceCache) isSelectedNamespace(raw interface{}) bool {
 	object, ok := raw.(*corev1.Namespace)
-	return ok &&
-		(object.ObjectMeta.Name == "ack-system" ||
-			object.ObjectMeta.Name == "kube-system" ||
-			object.ObjectMeta.Name == "kube-public")
+	return ok && c.filter.Matches(object)
 }
 
 // Run adds event handler functions to the SharedInformer and
@@ -91,7 +190,7 @@ func isIgnoredNamespace(raw interface{}) bool {
 func (c *NamespaceCache) Run(stopCh <-chan struct{}) {
 	c.informer.AddEventHandler(k8scache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			if !isIgnoredNamespace(obj) {
+			if c.isSelectedNamespace(obj) {
 				c.log.V(1).Info("namespace has been created")
 				c.setNamespaceInfoFromK8sObject(obj.(*corev1.Namespace))
 				c.log.V(1).Info("cached namespace ACK related annotations")
@@ -99,16 +198,19 @@ func (c *NamespaceCache) Run(stopCh <-chan struct{}) {
 		},
 
 		UpdateFunc: func(orig, desired interface{}) {
-			if !isIgnoredNamespace(desired) {
+			if c.isSelectedNamespace(desired) {
 				c.log.V(1).Info("namespace has been updated")
 				c.setNamespaceInfoFromK8sObject(desired.(*corev1.Namespace))
 				c.log.V(1).Info("cached namespace ACK related annotations")
+			} else if ns, ok := desired.(*corev1.Namespace); ok {
+				// The namespace's labels may no longer match the selector
+				c.deleteNamespaceInfo(ns.ObjectMeta.Name)
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
-			if !isIgnoredNamespace(obj) {
+			if ns, ok := obj.(*corev1.Namespace); ok {
 				c.log.V(1).Info("namespace has been deleted")
-				c.deleteNamespaceInfo(obj.(*corev1.Namespace).ObjectMeta.Name)
+				c.deleteNamespaceInfo(ns.ObjectMeta.Name)
 				c.log.V(1).Info("cleaned up namespace informations from cache")
 			}
 		},
@@ -116,6 +218,27 @@ func (c *NamespaceCache) Run(stopCh <-chan struct{}) {
 	go c.informer.Run(stopCh)
 }
 
+// HasSynced returns true if the cache has been populated with the
+// namespaces that existed when it started running
+func (c *NamespaceCache) HasSynced() bool {
+	return c.informer.HasSynced()
+}
+
+// IsIgnored returns true if the CRs in the namespace with the supplied name
+// must not be reconciled, because the namespace is one of the ignored
+// namespaces or its labels don't match the label selector
+func (c *NamespaceCache) IsIgnored(namespace string) bool {
+	if c.filter.isIgnored(namespace) {
+		return true
+	}
+	if !c.filter.hasSelector() {
+		return false
+	}
+	// Only the namespaces that match the label selector are cached
+	_, ok := c.getNamespaceInfo(namespace)
+	return !ok
+}
+
 // GetDefaultRegion returns the default region if it it exists
 func (c *NamespaceCache) GetDefaultRegion(namespace string) (string, bool) {
 	info, ok := c.getNamespaceInfo(namespace)
@@ -136,6 +259,56 @@ func (c *NamespaceCache) GetOwnerAccountID(namespace string) (string, bool) {
 	return "", false
 }
 
+// GetDefaultResourceTags returns the comma-separated list of key=value pairs
+// of default resource tags if it exists
+func (c *NamespaceCache) GetDefaultResourceTags(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		t := info.getDefaultResourceTags()
+		return t, t != ""
+	}
+	return "", false
+}
+
+// GetEndpointURL returns the AWS service API endpoint URL if it exists
+func (c *NamespaceCache) GetEndpointURL(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		e := info.getEndpointURL()
+		return e, e != ""
+	}
+	return "", false
+}
+
+// GetCredentialsProvider returns the description of the AWS credentials
+// provider if it exists
+func (c *NamespaceCache) GetCredentialsProvider(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		p := info.getCredentialsProvider()
+		return p, p != ""
+	}
+	return "", false
+}
+
+// IsReconcilePaused returns true if reconciliation of the CRs in the
+// namespace is paused
+func (c *NamespaceCache) IsReconcilePaused(namespace string) bool {
+	info, _ := c.getNamespaceInfo(namespace)
+	return info.isReconcilePaused()
+}
+
+// GetDriftPolicy returns the drift policy of the CRs in the namespace if it
+// exists
+func (c *NamespaceCache) GetDriftPolicy(namespace string) (string, bool) {
+	info, ok := c.getNamespaceInfo(namespace)
+	if ok {
+		p := info.getDriftPolicy()
+		return p, p != ""
+	}
+	return "", false
+}
+
 // getNamespaceInfo reads a namespace cached annotations and
 // return a given namespace default aws region and owner account id.
 // This function is thread safe.
@@ -159,6 +332,26 @@ func (c *NamespaceCache) setNamespaceInfoFromK8sObject(ns *corev1.Namespace) {
 	if ok {
 		nsInfo.ownerAccountID = OwnerAccountID
 	}
+	DefaultResourceTags, ok := nsa[ackv1alpha1.AnnotationDefaultResourceTags]
+	if ok {
+		nsInfo.defaultResourceTags = DefaultResourceTags
+	}
+	EndpointURL, ok := nsa[ackv1alpha1.AnnotationEndpointURL]
+	if ok {
+		nsInfo.endpointURL = EndpointURL
+	}
+	CredentialsProvider, ok := nsa[ackv1alpha1.AnnotationCredentialsProvider]
+	if ok {
+		nsInfo.credentialsProvider = CredentialsProvider
+	}
+	ReconcilePaused, ok := nsa[ackv1alpha1.AnnotationReconcilePaused]
+	if ok {
+		nsInfo.reconcilePaused, _ = strconv.ParseBool(ReconcilePaused)
+	}
+	DriftPolicy, ok := nsa[ackv1alpha1.AnnotationDriftPolicy]
+	if ok {
+		nsInfo.driftPolicy = DriftPolicy
+	}
 	c.Lock()
 	defer c.Unlock()
 	c.namespaceInfos[ns.ObjectMeta.Name] = nsInfo
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/watch"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
@@ -49,7 +50,10 @@ func TestNamespaceCache(t *testing.T) {
 	fakeLogger := ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions))
 
 	// initlizing account cache
-	namespaceCache := ackrtcache.NewNamespaceCache(k8sClient, fakeLogger)
+	namespaceCache := ackrtcache.NewNamespaceCache(
+		k8sClient, fakeLogger,
+		ackrtcache.NamespaceFilter{IgnoredNamespaces: ackrtcache.DefaultIgnoredNamespaces()},
+	)
 	stopCh := make(chan struct{})
 
 	namespaceCache.Run(stopCh)
@@ -61,8 +65,13 @@ func TestNamespaceCache(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "production",
 				Annotations: map[string]string{
-					ackv1alpha1.AnnotationDefaultRegion:  "us-west-2",
-					ackv1alpha1.AnnotationOwnerAccountID: "012345678912",
+					ackv1alpha1.AnnotationDefaultRegion:       "us-west-2",
+					ackv1alpha1.AnnotationOwnerAccountID:      "012345678912",
+					ackv1alpha1.AnnotationDefaultResourceTags: "team=storage,env=dev",
+					ackv1alpha1.AnnotationEndpointURL:         "http://localstack:4566",
+					ackv1alpha1.AnnotationCredentialsProvider: "secret:aws-credentials",
+					ackv1alpha1.AnnotationReconcilePaused:     "true",
+					ackv1alpha1.AnnotationDriftPolicy:         "report",
 				},
 			},
 		},
@@ -79,6 +88,24 @@ func TestNamespaceCache(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "012345678912", ownerAccountID)
 
+	defaultResourceTags, ok := namespaceCache.GetDefaultResourceTags("production")
+	require.True(t, ok)
+	require.Equal(t, "team=storage,env=dev", defaultResourceTags)
+
+	endpointURL, ok := namespaceCache.GetEndpointURL("production")
+	require.True(t, ok)
+	require.Equal(t, "http://localstack:4566", endpointURL)
+
+	credentialsProvider, ok := namespaceCache.GetCredentialsProvider("production")
+	require.True(t, ok)
+	require.Equal(t, "secret:aws-credentials", credentialsProvider)
+
+	require.True(t, namespaceCache.IsReconcilePaused("production"))
+
+	driftPolicy, ok := namespaceCache.GetDriftPolicy("production")
+	require.True(t, ok)
+	require.Equal(t, "report", driftPolicy)
+
 	// Test update events
 	k8sClient.CoreV1().Namespaces().Update(
 		context.Background(),
@@ -104,6 +131,20 @@ func TestNamespaceCache(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "21987654321", ownerAccountID)
 
+	_, ok = namespaceCache.GetDefaultResourceTags("production")
+	require.False(t, ok)
+
+	_, ok = namespaceCache.GetEndpointURL("production")
+	require.False(t, ok)
+
+	_, ok = namespaceCache.GetCredentialsProvider("production")
+	require.False(t, ok)
+
+	require.False(t, namespaceCache.IsReconcilePaused("production"))
+
+	_, ok = namespaceCache.GetDriftPolicy("production")
+	require.False(t, ok)
+
 	// Test delete events
 	k8sClient.CoreV1().Namespaces().Delete(
 		context.Background(),
@@ -116,3 +157,78 @@ func TestNamespaceCache(t *testing.T) {
 	_, ok = namespaceCache.GetDefaultRegion(testNamespace1)
 	require.False(t, ok)
 }
+
+func TestNamespaceCacheFilter(t *testing.T) {
+	k8sClient := k8sfake.NewSimpleClientset()
+
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	fakeLogger := ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions))
+
+	selector, err := labels.Parse("team=storage")
+	require.Nil(t, err)
+	namespaceCache := ackrtcache.NewNamespaceCache(
+		k8sClient, fakeLogger,
+		ackrtcache.NamespaceFilter{
+			IgnoredNamespaces: []string{"kube-system", "storage-system"},
+			Selector:          selector,
+		},
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	namespaceCache.Run(stopCh)
+
+	for name, team := range map[string]string{
+		"production":     "storage",
+		"staging":        "compute",
+		"storage-system": "storage",
+	} {
+		k8sClient.CoreV1().Namespaces().Create(
+			context.Background(),
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"team": team},
+					Annotations: map[string]string{
+						ackv1alpha1.AnnotationDefaultRegion: "us-west-2",
+					},
+				},
+			},
+			metav1.CreateOptions{},
+		)
+	}
+
+	time.Sleep(time.Second)
+
+	require.True(t, namespaceCache.HasSynced())
+	require.False(t, namespaceCache.IsIgnored("production"))
+	require.True(t, namespaceCache.IsIgnored("staging"))
+	require.True(t, namespaceCache.IsIgnored("storage-system"))
+	require.True(t, namespaceCache.IsIgnored("kube-system"))
+	require.True(t, namespaceCache.IsIgnored("unknown"))
+
+	// Annotations of namespaces that aren't selected aren't cached
+	_, ok := namespaceCache.GetDefaultRegion("production")
+	require.True(t, ok)
+	_, ok = namespaceCache.GetDefaultRegion("staging")
+	require.False(t, ok)
+
+	// Namespaces are no longer selected when their labels stop matching
+	k8sClient.CoreV1().Namespaces().Update(
+		context.Background(),
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "production",
+				Labels: map[string]string{"team": "compute"},
+			},
+		},
+		metav1.UpdateOptions{},
+	)
+
+	time.Sleep(time.Second)
+
+	require.True(t, namespaceCache.IsIgnored("production"))
+}
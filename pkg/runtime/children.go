@@ -0,0 +1,248 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// eventReasonParentDeleted is the reason of the event emitted when the
+	// backend AWS service API resource of a child resource was deleted along
+	// with its parent
+	eventReasonParentDeleted = "ParentDeleted"
+)
+
+// ensureParentReference makes the CR of the parent resource of the supplied
+// resource, if it's a child resource, an owner of the resource's CR, so that
+// the child's CR is garbage collected along with the parent's. It returns
+// true if the resource's CR is owned by a parent's CR that has been, or is
+// being, deleted.
+func (r *reconciler) ensureParentReference(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (bool, error) {
+	child, ok := res.(acktypes.ChildAWSResource)
+	if !ok || child.ParentIdentifier() == "" {
+		return false, nil
+	}
+	parent, err := r.parentOf(ctx, child)
+	if err != nil {
+		return false, err
+	}
+	if parent == nil || parent.IsBeingDeleted() {
+		for _, ref := range res.MetaObject().GetOwnerReferences() {
+			if ref.Kind == child.ParentKind() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	parentMeta := parent.MetaObject()
+	for _, ref := range res.MetaObject().GetOwnerReferences() {
+		if ref.UID == parentMeta.GetUID() {
+			return false, nil
+		}
+	}
+	gvk, err := apiutil.GVKForObject(parent.RuntimeObject(), r.scheme)
+	if err != nil {
+		return false, err
+	}
+	orig := res.RuntimeObject().DeepCopyObject()
+	mo := res.MetaObject()
+	mo.SetOwnerReferences(append(mo.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       parentMeta.GetName(),
+		UID:        parentMeta.GetUID(),
+	}))
+	err = r.kc.Patch(ctx, res.RuntimeObject(), client.MergeFrom(orig))
+	if err != nil {
+		return false, err
+	}
+	r.log.V(1).Info(
+		"reconciler set parent reference",
+		"parent_kind", gvk.Kind,
+		"parent_name", parentMeta.GetName(),
+	)
+	return false, nil
+}
+
+// handleParentDeleted records on the supplied child resource, whose backend
+// AWS service API resource was deleted along with its parent's, a True
+// ACK.Terminal condition. The resource isn't created again: its CR is
+// garbage collected along with its parent's.
+func (r *reconciler) handleParentDeleted(
+	ctx context.Context,
+	current acktypes.AWSResource,
+) error {
+	child := current.(acktypes.ChildAWSResource)
+	msg := fmt.Sprintf(
+		"resource was deleted along with its parent %s %s",
+		child.ParentKind(), child.ParentIdentifier(),
+	)
+	latest := r.rd.ResourceFromRuntimeObject(
+		current.RuntimeObject().DeepCopyObject(),
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeTerminal, corev1.ConditionTrue, msg,
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionFalse, msg,
+	)
+	if r.rd.Equal(current, latest) {
+		return nil
+	}
+	r.log.V(0).Info("resource was deleted along with its parent")
+	r.recordEvent(
+		latest, corev1.EventTypeWarning, eventReasonParentDeleted,
+		"The AWS resource was deleted along with its parent "+
+			child.ParentKind()+" "+child.ParentIdentifier(),
+	)
+	return r.patchResourceStatus(ctx, current, latest)
+}
+
+// deleteChildren deletes the CRs of the child resources of the supplied
+// resource, if it's a parent resource, one kind at a time in the order of
+// its ChildKinds. It returns an error requeueing the resource until the CRs
+// of all the child resources are gone.
+func (r *reconciler) deleteChildren(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) error {
+	parent, ok := res.(acktypes.ParentAWSResource)
+	if !ok || parent.ChildIdentifier() == "" {
+		return nil
+	}
+	for _, kind := range parent.ChildKinds() {
+		children, err := r.childrenOf(ctx, parent, kind)
+		if err != nil {
+			return err
+		}
+		if len(children) == 0 {
+			continue
+		}
+		for _, child := range children {
+			if child.IsBeingDeleted() {
+				continue
+			}
+			err = r.kc.Delete(ctx, child.RuntimeObject())
+			if err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			r.log.V(0).Info(
+				"reconciler.cleanup deleted child resource",
+				"child_kind", kind,
+				"child_name", child.MetaObject().GetName(),
+			)
+		}
+		return requeue.NeededWithBackoff(
+			fmt.Errorf(
+				"%w: %d %s", ackerr.ChildResourcesBeingDeleted,
+				len(children), kind,
+			),
+			requeue.DefaultBackoff,
+		)
+	}
+	return nil
+}
+
+// parentOf returns the CR of the parent resource of the supplied child
+// resource, in the child's namespace, or nil if there's none
+func (r *reconciler) parentOf(
+	ctx context.Context,
+	child acktypes.ChildAWSResource,
+) (acktypes.AWSResource, error) {
+	candidates, err := r.listRelated(
+		ctx, child.ParentKind(), child.MetaObject().GetNamespace(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		parent, ok := candidate.(acktypes.ParentAWSResource)
+		if ok && parent.ChildIdentifier() == child.ParentIdentifier() {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// childrenOf returns the CRs of the child resources of the supplied kind of
+// the supplied parent resource, in the parent's namespace
+func (r *reconciler) childrenOf(
+	ctx context.Context,
+	parent acktypes.ParentAWSResource,
+	kind string,
+) ([]acktypes.AWSResource, error) {
+	candidates, err := r.listRelated(
+		ctx, kind, parent.MetaObject().GetNamespace(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	children := []acktypes.AWSResource{}
+	for _, candidate := range candidates {
+		child, ok := candidate.(acktypes.ChildAWSResource)
+		if ok && child.ParentKind() == r.rd.GroupKind().Kind &&
+			child.ParentIdentifier() == parent.ChildIdentifier() {
+			children = append(children, candidate)
+		}
+	}
+	return children, nil
+}
+
+// listRelated returns the CRs of the supplied kind of resource of the same
+// AWS service API in the supplied namespace. Kinds that aren't related to the
+// reconciled kind, or whose CRD isn't installed, have no CRs.
+func (r *reconciler) listRelated(
+	ctx context.Context,
+	kind string,
+	namespace string,
+) ([]acktypes.AWSResource, error) {
+	rd, found := r.related[kind]
+	if !found {
+		return nil, nil
+	}
+	index, err := newResourceIndex(r.kc, r.scheme, rd.EmptyRuntimeObject())
+	if err != nil {
+		return nil, err
+	}
+	items, err := index.list(ctx, client.InNamespace(namespace))
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resources := make([]acktypes.AWSResource, 0, len(items))
+	for _, item := range items {
+		resources = append(resources, rd.ResourceFromRuntimeObject(item))
+	}
+	return resources, nil
+}
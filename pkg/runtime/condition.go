@@ -0,0 +1,141 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+// GetCondition returns the condition of the supplied type from the supplied
+// resource's Conditions collection, or nil if the resource has no condition
+// of that type
+func GetCondition(
+	res acktypes.AWSResource,
+	condType ackv1alpha1.ConditionType,
+) *ackv1alpha1.Condition {
+	for _, c := range res.Conditions() {
+		if c.Type == condType {
+			return c
+		}
+	}
+	return nil
+}
+
+// SetCondition sets the status and message of the condition of the supplied
+// type in the supplied resource's Conditions collection, adding the condition
+// to the collection if it doesn't exist. The condition's last transition time
+// is updated when its status changes.
+func SetCondition(
+	res acktypes.AWSResource,
+	condType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	message string,
+) {
+	c := GetCondition(res, condType)
+	isNew := c == nil
+	if isNew {
+		c = &ackv1alpha1.Condition{Type: condType}
+	}
+	if c.Status != status {
+		now := metav1.Now()
+		c.LastTransitionTime = &now
+		c.Status = status
+	}
+	if message == "" {
+		c.Message = nil
+	} else {
+		c.Message = &message
+	}
+	if isNew {
+		res.SetConditions(append(res.Conditions(), c))
+	}
+}
+
+// isStable returns true if the backend AWS service API resource represented
+// by the supplied resource is in a stable state. Resources that don't go
+// through transitional states are always stable.
+func isStable(res acktypes.AWSResource) bool {
+	stateful, ok := res.(acktypes.StatefulAWSResource)
+	return !ok || stateful.IsStable()
+}
+
+// isFailed returns true if the backend AWS service API resource represented
+// by the supplied resource is in a terminal state from which it will not
+// recover
+func isFailed(res acktypes.AWSResource) bool {
+	stateful, ok := res.(acktypes.StatefulAWSResource)
+	return ok && stateful.IsFailed()
+}
+
+// setSyncConditions sets the ACK.ResourceSynced and ACK.Terminal conditions
+// of the supplied resource, which has just been synced with its backend AWS
+// service API resource. The resource is only synced when the backend AWS
+// service API resource is in a stable state.
+func (r *reconciler) setSyncConditions(res acktypes.AWSResource) {
+	if isStable(res) {
+		SetCondition(
+			res, ackv1alpha1.ConditionTypeResourceSynced,
+			corev1.ConditionTrue, "",
+		)
+	} else {
+		state := res.(acktypes.StatefulAWSResource).State()
+		SetCondition(
+			res, ackv1alpha1.ConditionTypeResourceSynced,
+			corev1.ConditionFalse,
+			fmt.Sprintf("resource is in %q state", state),
+		)
+	}
+	if isFailed(res) {
+		state := res.(acktypes.StatefulAWSResource).State()
+		SetCondition(
+			res, ackv1alpha1.ConditionTypeTerminal,
+			corev1.ConditionTrue,
+			fmt.Sprintf("resource is in terminal %q state", state),
+		)
+	} else if GetCondition(res, ackv1alpha1.ConditionTypeTerminal) != nil {
+		SetCondition(
+			res, ackv1alpha1.ConditionTypeTerminal,
+			corev1.ConditionFalse, "",
+		)
+	}
+}
+
+// requeueIfNotStable returns an error instructing the controller-runtime to
+// requeue the supplied resource, with the default backoff, if its backend AWS
+// service API resource is transitioning between states, or nil otherwise.
+// Resources in a terminal state are not requeued.
+func requeueIfNotStable(res acktypes.AWSResource) error {
+	if isStable(res) || isFailed(res) {
+		return nil
+	}
+	return requeue.NeededWithBackoff(
+		ackerr.ResourceNotStable, requeue.DefaultBackoff,
+	)
+}
+
+// isDeleting returns true if the supplied resource has an ACK.Deleting
+// condition with a True status, which indicates that the backend AWS service
+// API resource is being deleted
+func isDeleting(res acktypes.AWSResource) bool {
+	deleting := GetCondition(res, ackv1alpha1.ConditionTypeDeleting)
+	return deleting != nil && deleting.Status == corev1.ConditionTrue
+}
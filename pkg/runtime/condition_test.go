@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestSetCondition(t *testing.T) {
+	require := require.New(t)
+
+	// Changing the status of an existing condition updates the transition
+	// time and message
+	synced := &ackv1alpha1.Condition{
+		Type:   ackv1alpha1.ConditionTypeResourceSynced,
+		Status: corev1.ConditionFalse,
+	}
+	res := &mocks.AWSResource{}
+	res.On("Conditions").Return([]*ackv1alpha1.Condition{synced})
+	ackrt.SetCondition(
+		res, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionTrue, "all good",
+	)
+	require.Equal(corev1.ConditionTrue, synced.Status)
+	require.NotNil(synced.LastTransitionTime)
+	require.Equal("all good", *synced.Message)
+	res.AssertNotCalled(t, "SetConditions", mock.Anything)
+
+	// Setting the same status again leaves the transition time alone
+	lastTransitionTime := synced.LastTransitionTime
+	ackrt.SetCondition(
+		res, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionTrue, "",
+	)
+	require.Equal(lastTransitionTime, synced.LastTransitionTime)
+	require.Nil(synced.Message)
+
+	// Conditions that don't exist are added
+	res = &mocks.AWSResource{}
+	res.On("Conditions").Return([]*ackv1alpha1.Condition{synced})
+	res.On("SetConditions", mock.MatchedBy(
+		func(conditions []*ackv1alpha1.Condition) bool {
+			return len(conditions) == 2 &&
+				conditions[1].Type == ackv1alpha1.ConditionTypeTerminal &&
+				conditions[1].Status == corev1.ConditionTrue
+		},
+	)).Return()
+	ackrt.SetCondition(
+		res, ackv1alpha1.ConditionTypeTerminal,
+		corev1.ConditionTrue, "create failed",
+	)
+	res.AssertExpectations(t)
+}
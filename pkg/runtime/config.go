@@ -14,12 +14,26 @@
 package runtime
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	flag "github.com/spf13/pflag"
+	gozap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubernetes "k8s.io/client-go/kubernetes"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackrtcache "github.com/aws/aws-controllers-k8s/pkg/runtime/cache"
 )
 
 const (
@@ -30,23 +44,119 @@ const (
 	flagAWSAccountID         = "aws-account-id"
 	flagAWSRegion            = "aws-region"
 	flagLogLevel             = "log-level"
+	flagEnableWebhooks       = "enable-webhooks"
+	flagResourceTags         = "resource-tags"
+	flagClusterID            = "cluster-id"
+	flagDeletionTimeout      = "deletion-timeout"
+	flagEndpointURL          = "endpoint-url"
+	flagServiceEndpointURLs  = "service-endpoint-urls"
+	flagCredentialsProvider  = "credentials-provider"
+	flagCredentialsFile      = "credentials-file"
+	flagWebIdentityTokenFile = "web-identity-token-file"
+	flagAuditLog             = "audit-log"
+	flagReadWhilePaused      = "read-while-paused"
+	flagResyncPeriod         = "resync-period"
+	flagReconcileRateLimit   = "reconcile-rate-limit"
+	flagReconcileRateBurst   = "reconcile-rate-burst"
+	flagConfigFile           = "config-file"
+	flagConfigMap            = "config-map"
+	flagPolicyConfigMap      = "policy-config-map"
+	flagIgnoredNamespaces    = "ignored-namespaces"
+	flagNamespaceSelector    = "namespace-selector"
+	flagEnableResources      = "enable-resources"
+	flagDisableResources     = "disable-resources"
+	flagOrphanScanPeriod     = "orphan-scan-period"
+	flagOrphanGracePeriod    = "orphan-deletion-grace-period"
+	flagDiscoveryNamespace   = "discovery-namespace"
+	flagDiscoveryNamePrefix  = "discovery-name-prefix"
+	flagDiscoveryTags        = "discovery-tags"
+	flagEventQueueURL        = "event-queue-url"
 )
 
+const (
+	// ConfigMapKey is the key of the YAML configuration in the ConfigMap
+	// containing the service controller's configuration
+	ConfigMapKey = "config.yaml"
+)
+
+// logLevel is the level of the service controller's logger. It can be
+// changed while the service controller is running.
+var logLevel = gozap.NewAtomicLevel()
+
+// Config contains the service controller's configuration. It's read from
+// command line flags and, optionally, from a YAML configuration file and a
+// ConfigMap, whose fields are named after the JSON tags below. Values in the
+// configuration file take precedence over the flags, and values in the
+// ConfigMap take precedence over both.
+//
+// The LogLevel, DeletionTimeout, ReadWhilePaused, ResyncPeriod,
+// ReconcileRateLimit, ReconcileRateBurst and Resources settings are reloaded
+// when the configuration file or the ConfigMap changes. Changes to other
+// settings require a restart.
 type Config struct {
-	BindPort                 int
-	MetricsAddr              string
-	EnableLeaderElection     bool
-	EnableDevelopmentLogging bool
-	AccountID                string
-	Region                   string
-	LogLevel                 string
+	BindPort                 int                       `json:"bindPort"`
+	MetricsAddr              string                    `json:"metricsAddr"`
+	EnableLeaderElection     bool                      `json:"enableLeaderElection"`
+	EnableDevelopmentLogging bool                      `json:"enableDevelopmentLogging"`
+	AccountID                string                    `json:"awsAccountID"`
+	Region                   string                    `json:"awsRegion"`
+	LogLevel                 string                    `json:"logLevel"`
+	EnableWebhooks           bool                      `json:"enableWebhooks"`
+	ResourceTags             []string                  `json:"resourceTags"`
+	ClusterID                string                    `json:"clusterID"`
+	DeletionTimeout          metav1.Duration           `json:"deletionTimeout"`
+	EndpointURL              string                    `json:"endpointURL"`
+	ServiceEndpointURLs      []string                  `json:"serviceEndpointURLs"`
+	CredentialsProvider      string                    `json:"credentialsProvider"`
+	CredentialsFile          string                    `json:"credentialsFile"`
+	WebIdentityTokenFile     string                    `json:"webIdentityTokenFile"`
+	AuditLog                 string                    `json:"auditLog"`
+	ReadWhilePaused          bool                      `json:"readWhilePaused"`
+	ResyncPeriod             metav1.Duration           `json:"resyncPeriod"`
+	ReconcileRateLimit       float64                   `json:"reconcileRateLimit"`
+	ReconcileRateBurst       int                       `json:"reconcileRateBurst"`
+	Resources                map[string]ResourceConfig `json:"resources"`
+	ConfigFile               string                    `json:"-"`
+	ConfigMap                string                    `json:"-"`
+	PolicyConfigMap          string                    `json:"policyConfigMap"`
+	IgnoredNamespaces        []string                  `json:"ignoredNamespaces"`
+	NamespaceSelector        string                    `json:"namespaceSelector"`
+	EnableResources          []string                  `json:"enableResources"`
+	DisableResources         []string                  `json:"disableResources"`
+	OrphanScanPeriod         metav1.Duration           `json:"orphanScanPeriod"`
+	OrphanGracePeriod        metav1.Duration           `json:"orphanGracePeriod"`
+	DiscoveryNamespace       string                    `json:"discoveryNamespace"`
+	DiscoveryNamePrefix      string                    `json:"discoveryNamePrefix"`
+	DiscoveryTags            []string                  `json:"discoveryTags"`
+	EventQueueURL            string                    `json:"eventQueueURL"`
+
+	// flagValues is the configuration read from the command line flags,
+	// before the configuration file and ConfigMap were read
+	flagValues *Config
+	// fileData and configMapData are the YAML configurations read from the
+	// configuration file and the ConfigMap
+	fileData      []byte
+	configMapData []byte
+}
+
+// ResourceConfig contains settings that override the service controller's
+// settings for a single kind of resource. The Resources field of the Config
+// maps GroupKinds, e.g. "Repository.ecr.services.k8s.aws", to these
+// overrides.
+type ResourceConfig struct {
+	DeletionTimeout    *metav1.Duration `json:"deletionTimeout,omitempty"`
+	ReadWhilePaused    *bool            `json:"readWhilePaused,omitempty"`
+	ResyncPeriod       *metav1.Duration `json:"resyncPeriod,omitempty"`
+	ReconcileRateLimit *float64         `json:"reconcileRateLimit,omitempty"`
+	ReconcileRateBurst *int             `json:"reconcileRateBurst,omitempty"`
 }
 
 func (cfg *Config) BindFlags() {
 	flag.IntVar(
 		&cfg.BindPort, flagBindPort,
 		9443,
-		"The port the service controller binds to.",
+		"The port the service controller binds to. The admission webhook "+
+			"server listens on this port when webhooks are enabled.",
 	)
 	flag.StringVar(
 		&cfg.MetricsAddr, flagMetricAddr,
@@ -80,25 +190,295 @@ func (cfg *Config) BindFlags() {
 		"info",
 		"The log level. Default is info. We use logr interface which only supports info and debug level",
 	)
+	flag.BoolVar(
+		&cfg.EnableWebhooks, flagEnableWebhooks,
+		false,
+		"Enable the validating and defaulting admission webhooks for the resources managed by the service controller. "+
+			"Requires a serving certificate in the controller manager's webhook certificate directory.",
+	)
+	flag.StringSliceVar(
+		&cfg.ResourceTags, flagResourceTags,
+		[]string{},
+		"Tags, in the form key=value, to add to all AWS resources created by the service controller",
+	)
+	flag.StringVar(
+		&cfg.ClusterID, flagClusterID,
+		"",
+		"An identifier, unique among the clusters sharing the service controller's AWS account and region, of the "+
+			"Kubernetes cluster. It's added to the ownership tags of the AWS resources created by the service "+
+			"controller and is required by the orphan scan.",
+	)
+	flag.DurationVar(
+		&cfg.DeletionTimeout.Duration, flagDeletionTimeout,
+		0,
+		"How long to wait for the AWS service API to delete a resource before removing the finalizer from its CR anyway. "+
+			"Zero means wait forever.",
+	)
+	flag.StringVar(
+		&cfg.EndpointURL, flagEndpointURL,
+		"",
+		"The URL of the AWS service API endpoint to call instead of the default endpoint, e.g. http://localhost:4566",
+	)
+	flag.StringSliceVar(
+		&cfg.ServiceEndpointURLs, flagServiceEndpointURLs,
+		[]string{},
+		"AWS service API endpoint URLs, in the form service=url, e.g. s3=http://localhost:9000, "+
+			"that take precedence over the --endpoint-url flag for the named services",
+	)
+	flag.StringVar(
+		&cfg.CredentialsProvider, flagCredentialsProvider,
+		CredentialsProviderDefault,
+		"Where the AWS credentials used to manage resources come from, unless overridden by a namespace annotation. "+
+			"One of default, secret:[<namespace>/]<name>, profile:<name> or web-identity:<role ARN>. "+
+			"Secrets without a namespace are in the namespace of the service controller",
+	)
+	flag.StringVar(
+		&cfg.CredentialsFile, flagCredentialsFile,
+		"",
+		"The path to the shared credentials file used by the profile credentials provider. "+
+			"Defaults to the AWS_SHARED_CREDENTIALS_FILE environment variable or ~/.aws/credentials",
+	)
+	flag.StringVar(
+		&cfg.WebIdentityTokenFile, flagWebIdentityTokenFile,
+		"",
+		"The path to the web identity token file used by the web-identity credentials provider. "+
+			"Defaults to the AWS_WEB_IDENTITY_TOKEN_FILE environment variable",
+	)
+	flag.StringVar(
+		&cfg.AuditLog, flagAuditLog,
+		"",
+		"Where to write the audit log of the AWS service API calls made to create, update and delete resources: "+
+			"the path of a file to append JSON lines to, or "+AuditLogStdout+". Disabled by default.",
+	)
+	flag.BoolVar(
+		&cfg.ReadWhilePaused, flagReadWhilePaused,
+		false,
+		"Keep reading the state of AWS resources, and updating the status of their CRs, while reconciliation is paused",
+	)
+	flag.DurationVar(
+		&cfg.ResyncPeriod.Duration, flagResyncPeriod,
+		0,
+		"How often to reconcile resources that are in sync, in order to detect changes made outside of the service controller. "+
+			"Zero means resources are only reconciled when their CR changes.",
+	)
+	flag.Float64Var(
+		&cfg.ReconcileRateLimit, flagReconcileRateLimit,
+		10,
+		"The maximum number of reconciliations per second for each kind of resource. Zero means no limit.",
+	)
+	flag.IntVar(
+		&cfg.ReconcileRateBurst, flagReconcileRateBurst,
+		100,
+		"The maximum number of reconciliations in a burst for each kind of resource",
+	)
+	flag.StringVar(
+		&cfg.ConfigFile, flagConfigFile,
+		"",
+		"The path to a YAML file containing the service controller's configuration, which takes precedence over the flags",
+	)
+	flag.StringVar(
+		&cfg.ConfigMap, flagConfigMap,
+		"",
+		"The name, in the form [<namespace>/]<name>, of a ConfigMap containing the service controller's configuration "+
+			"in its "+ConfigMapKey+" key, which takes precedence over the flags and the configuration file",
+	)
+	flag.StringVar(
+		&cfg.PolicyConfigMap, flagPolicyConfigMap,
+		"",
+		"The name, in the form [<namespace>/]<name>, of a ConfigMap containing policy rules in its "+
+			PolicyConfigMapKey+" key. Create, update and delete operations matching a rule are denied.",
+	)
+	flag.StringSliceVar(
+		&cfg.IgnoredNamespaces, flagIgnoredNamespaces,
+		ackrtcache.DefaultIgnoredNamespaces(),
+		"Namespaces whose CRs the service controller ignores. "+
+			"Defaults to the namespace the service controller runs in, kube-system and kube-public.",
+	)
+	flag.StringVar(
+		&cfg.NamespaceSelector, flagNamespaceSelector,
+		"",
+		"A label selector, e.g. team=storage, selecting the namespaces whose CRs the service controller reconciles. "+
+			"CRs in other namespaces are ignored. Selects all namespaces by default.",
+	)
+	flag.StringSliceVar(
+		&cfg.EnableResources, flagEnableResources,
+		[]string{},
+		"The kinds of resources the service controller manages, either as a kind, e.g. Repository, or a GroupKind, "+
+			"e.g. Repository.ecr.services.k8s.aws. Defaults to all the kinds of resources of the service.",
+	)
+	flag.StringSliceVar(
+		&cfg.DisableResources, flagDisableResources,
+		[]string{},
+		"The kinds of resources the service controller doesn't manage, either as a kind or a GroupKind. "+
+			"Takes precedence over the --"+flagEnableResources+" flag.",
+	)
+	flag.DurationVar(
+		&cfg.OrphanScanPeriod.Duration, flagOrphanScanPeriod,
+		0,
+		"How often to look for AWS resources, in the service controller's account and region, whose ownership tags "+
+			"claim they're managed by the service controller but whose CR doesn't exist. Zero disables the scan.",
+	)
+	flag.DurationVar(
+		&cfg.OrphanGracePeriod.Duration, flagOrphanGracePeriod,
+		0,
+		"How long an AWS resource found by the orphan scan must stay orphaned before the service controller deletes it. "+
+			"Zero means orphaned resources are only reported, never deleted.",
+	)
+	flag.StringVar(
+		&cfg.DiscoveryNamespace, flagDiscoveryNamespace,
+		"",
+		"The namespace in which to create CRs, with the "+ackv1alpha1.AnnotationARN+" annotation that adopts them, for the "+
+			"existing AWS resources in the service controller's account and region that have no CR. "+
+			"Discovery runs once when the service controller starts and is disabled by default.",
+	)
+	flag.StringVar(
+		&cfg.DiscoveryNamePrefix, flagDiscoveryNamePrefix,
+		"",
+		"Only discover AWS resources whose name starts with this prefix",
+	)
+	flag.StringSliceVar(
+		&cfg.DiscoveryTags, flagDiscoveryTags,
+		[]string{},
+		"Tags, in the form key=value, that AWS resources must all have to be discovered",
+	)
+	flag.StringVar(
+		&cfg.EventQueueURL, flagEventQueueURL,
+		"",
+		"The URL of an SQS queue receiving AWS resource change notifications, e.g. CloudTrail events delivered by "+
+			"EventBridge. The CRs of the resources named by the notifications are reconciled right away. "+
+			"The SQS endpoint can be overridden with the --"+flagServiceEndpointURLs+" flag. Disabled by default.",
+	)
 }
 
-func (cfg *Config) SetupLogger() {
-	var lvl zapcore.LevelEnabler
+// Load reads the configuration file and the ConfigMap, if any, into the
+// Config. It must be called after the flags are parsed.
+func (cfg *Config) Load() error {
+	flagValues := cfg.DeepCopy()
+	cfg.flagValues = flagValues
+	if cfg.ConfigFile != "" {
+		data, err := ioutil.ReadFile(cfg.ConfigFile)
+		if err != nil {
+			return fmt.Errorf("unable to read configuration file: %v", err)
+		}
+		if err = cfg.unmarshal(data); err != nil {
+			return fmt.Errorf("invalid configuration file %s: %v", cfg.ConfigFile, err)
+		}
+		cfg.fileData = data
+	}
+	if cfg.ConfigMap != "" {
+		restCfg, err := ctrlrt.GetConfig()
+		if err != nil {
+			return err
+		}
+		clientset, err := kubernetes.NewForConfig(restCfg)
+		if err != nil {
+			return err
+		}
+		ns, name := cfg.configMapName()
+		cm, err := clientset.CoreV1().ConfigMaps(ns).Get(
+			context.TODO(), name, metav1.GetOptions{},
+		)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to read configuration ConfigMap: %v", err)
+		}
+		if err == nil {
+			data := []byte(cm.Data[ConfigMapKey])
+			if err = cfg.unmarshal(data); err != nil {
+				return fmt.Errorf("invalid configuration ConfigMap %s: %v", cfg.ConfigMap, err)
+			}
+			cfg.configMapData = data
+		}
+	}
+	return nil
+}
 
-	switch cfg.LogLevel {
-	case "debug":
-		lvl = zapcore.DebugLevel
-	default:
-		lvl = zapcore.InfoLevel
+// DeepCopy returns a copy of the Config
+func (cfg *Config) DeepCopy() *Config {
+	res := *cfg
+	res.ResourceTags = append([]string{}, cfg.ResourceTags...)
+	res.ServiceEndpointURLs = append([]string{}, cfg.ServiceEndpointURLs...)
+	res.IgnoredNamespaces = append([]string{}, cfg.IgnoredNamespaces...)
+	res.EnableResources = append([]string{}, cfg.EnableResources...)
+	res.DisableResources = append([]string{}, cfg.DisableResources...)
+	res.DiscoveryTags = append([]string{}, cfg.DiscoveryTags...)
+	res.Resources = make(map[string]ResourceConfig, len(cfg.Resources))
+	for gk, resCfg := range cfg.Resources {
+		res.Resources[gk] = resCfg
 	}
+	return &res
+}
+
+// unmarshal reads the supplied YAML configuration into the Config. Settings
+// that aren't in the YAML configuration keep their values.
+func (cfg *Config) unmarshal(data []byte) error {
+	return yaml.Unmarshal(data, cfg)
+}
+
+// configMapName returns the namespace and name of the ConfigMap containing
+// the service controller's configuration. The namespace defaults to the
+// namespace the service controller runs in.
+func (cfg *Config) configMapName() (string, string) {
+	return splitConfigMapName(cfg.ConfigMap)
+}
+
+// splitConfigMapName returns the namespace and name of a ConfigMap from the
+// supplied string in the form [<namespace>/]<name>. The namespace defaults to
+// the namespace the service controller runs in.
+func splitConfigMapName(val string) (string, string) {
+	parts := strings.SplitN(val, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return ackrtcache.CurrentNamespace(), val
+}
+
+// forGroupKind returns a copy of the Config with the overrides for the
+// supplied GroupKind applied
+func (cfg *Config) forGroupKind(gk *metav1.GroupKind) Config {
+	res := *cfg
+	resCfg, found := cfg.Resources[gk.String()]
+	if !found {
+		return res
+	}
+	if resCfg.DeletionTimeout != nil {
+		res.DeletionTimeout = *resCfg.DeletionTimeout
+	}
+	if resCfg.ReadWhilePaused != nil {
+		res.ReadWhilePaused = *resCfg.ReadWhilePaused
+	}
+	if resCfg.ResyncPeriod != nil {
+		res.ResyncPeriod = *resCfg.ResyncPeriod
+	}
+	if resCfg.ReconcileRateLimit != nil {
+		res.ReconcileRateLimit = *resCfg.ReconcileRateLimit
+	}
+	if resCfg.ReconcileRateBurst != nil {
+		res.ReconcileRateBurst = *resCfg.ReconcileRateBurst
+	}
+	return res
+}
+
+func (cfg *Config) SetupLogger() {
+	setLogLevel(cfg.LogLevel)
 
 	zapOptions := zap.Options{
 		Development: cfg.EnableDevelopmentLogging,
-		Level:       lvl,
+		Level:       logLevel,
 	}
 	ctrlrt.SetLogger(zap.New(zap.UseFlagOptions(&zapOptions)))
 }
 
+// setLogLevel sets the level of the service controller's logger to the level
+// with the supplied name
+func setLogLevel(name string) {
+	switch name {
+	case "debug":
+		logLevel.SetLevel(zapcore.DebugLevel)
+	default:
+		logLevel.SetLevel(zapcore.InfoLevel)
+	}
+}
+
 func (cfg *Config) Validate() error {
 	if cfg.AccountID == "" {
 		return errors.New("unable to start service controller as account ID is nil. Please pass --aws-account-id flag")
@@ -106,5 +486,162 @@ func (cfg *Config) Validate() error {
 	if cfg.Region == "" {
 		return errors.New("unable to start service controller as AWS region is nil. Please pass --aws-region flag")
 	}
+	if _, err := parseTags(cfg.ResourceTags); err != nil {
+		return fmt.Errorf("invalid value for --%s flag: %v", flagResourceTags, err)
+	}
+	if cfg.EndpointURL != "" {
+		if err := validateEndpointURL(cfg.EndpointURL); err != nil {
+			return fmt.Errorf("invalid value for --%s flag: %v", flagEndpointURL, err)
+		}
+	}
+	svcEndpointURLs, err := parseKeyValuePairs(cfg.ServiceEndpointURLs)
+	if err != nil {
+		return fmt.Errorf("invalid value for --%s flag: %v", flagServiceEndpointURLs, err)
+	}
+	for _, endpointURL := range svcEndpointURLs {
+		if err := validateEndpointURL(endpointURL); err != nil {
+			return fmt.Errorf("invalid value for --%s flag: %v", flagServiceEndpointURLs, err)
+		}
+	}
+	if _, err := parseCredentialsProvider(cfg.CredentialsProvider, ""); err != nil {
+		return fmt.Errorf("invalid value for --%s flag: %v", flagCredentialsProvider, err)
+	}
+	if cfg.ReconcileRateLimit < 0 {
+		return fmt.Errorf("invalid value for --%s flag: must not be negative", flagReconcileRateLimit)
+	}
+	if cfg.ReconcileRateLimit > 0 && cfg.ReconcileRateBurst < 1 {
+		return fmt.Errorf("invalid value for --%s flag: must be at least 1", flagReconcileRateBurst)
+	}
+	if _, err := labels.Parse(cfg.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid value for --%s flag: %v", flagNamespaceSelector, err)
+	}
+	if cfg.OrphanScanPeriod.Duration < 0 {
+		return fmt.Errorf("invalid value for --%s flag: must not be negative", flagOrphanScanPeriod)
+	}
+	if cfg.OrphanGracePeriod.Duration < 0 {
+		return fmt.Errorf("invalid value for --%s flag: must not be negative", flagOrphanGracePeriod)
+	}
+	if cfg.ClusterID == "" {
+		// Without the cluster ID, the orphan scan would report, and delete,
+		// the resources of other clusters in the same account and region
+		if cfg.OrphanScanPeriod.Duration > 0 {
+			return fmt.Errorf("the --%s flag requires the --%s flag", flagOrphanScanPeriod, flagClusterID)
+		}
+		if cfg.OrphanGracePeriod.Duration > 0 {
+			return fmt.Errorf("the --%s flag requires the --%s flag", flagOrphanGracePeriod, flagClusterID)
+		}
+	}
+	if _, err := parseTags(cfg.DiscoveryTags); err != nil {
+		return fmt.Errorf("invalid value for --%s flag: %v", flagDiscoveryTags, err)
+	}
+	if cfg.EventQueueURL != "" {
+		if err := validateEndpointURL(cfg.EventQueueURL); err != nil {
+			return fmt.Errorf("invalid value for --%s flag: %v", flagEventQueueURL, err)
+		}
+	}
+	return nil
+}
+
+// resourceEnabled returns true if the service controller manages the kind of
+// resources with the supplied GroupKind, according to the
+// --enable-resources and --disable-resources flags
+func (cfg *Config) resourceEnabled(gk *metav1.GroupKind) bool {
+	if matchesGroupKind(cfg.DisableResources, gk) {
+		return false
+	}
+	return len(cfg.EnableResources) == 0 ||
+		matchesGroupKind(cfg.EnableResources, gk)
+}
+
+// ValidateResources returns an error if any of the kinds in the
+// --enable-resources or --disable-resources flags matches none of the
+// supplied GroupKinds of the resources managed by the service controllers
+func (cfg *Config) ValidateResources(gks []*metav1.GroupKind) error {
+	for _, f := range []struct {
+		name string
+		vals []string
+	}{
+		{flagEnableResources, cfg.EnableResources},
+		{flagDisableResources, cfg.DisableResources},
+	} {
+		unmatched := []string{}
+		for _, val := range f.vals {
+			matched := false
+			for _, gk := range gks {
+				if matchesGroupKind([]string{val}, gk) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				unmatched = append(unmatched, val)
+			}
+		}
+		if len(unmatched) > 0 {
+			return fmt.Errorf(
+				"invalid value for --%s flag: %s matches no kind of resource managed by the service controllers",
+				f.name, strings.Join(unmatched, ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// matchesGroupKind returns true if any of the supplied kinds or GroupKinds,
+// e.g. "Repository" or "Repository.ecr.services.k8s.aws", matches the
+// supplied GroupKind. Kinds are matched case-insensitively.
+func matchesGroupKind(vals []string, gk *metav1.GroupKind) bool {
+	for _, val := range vals {
+		if strings.EqualFold(val, gk.Kind) || strings.EqualFold(val, gk.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceFilter returns the NamespaceFilter selecting the namespaces whose
+// CRs are reconciled
+func (cfg *Config) namespaceFilter() ackrtcache.NamespaceFilter {
+	// Config.Validate() ensures the label selector is well-formed
+	selector, _ := labels.Parse(cfg.NamespaceSelector)
+	return ackrtcache.NamespaceFilter{
+		IgnoredNamespaces: cfg.IgnoredNamespaces,
+		Selector:          selector,
+	}
+}
+
+// discoveryFilter returns the DiscoveryFilter selecting the AWS resources to
+// create CRs for
+func (cfg *Config) discoveryFilter() DiscoveryFilter {
+	// Config.Validate() ensures the flag values are well-formed
+	tags, _ := parseTags(cfg.DiscoveryTags)
+	return DiscoveryFilter{
+		NamePrefix: cfg.DiscoveryNamePrefix,
+		Tags:       tags,
+	}
+}
+
+// serviceEndpointURL returns the URL of the endpoint to call for the AWS
+// service API with the supplied alias, or the empty string if the default
+// endpoint should be called
+func (cfg *Config) serviceEndpointURL(svcAlias string) string {
+	// Config.Validate() ensures the flag values are well-formed
+	svcEndpointURLs, _ := parseKeyValuePairs(cfg.ServiceEndpointURLs)
+	if endpointURL, found := svcEndpointURLs[svcAlias]; found {
+		return endpointURL
+	}
+	return cfg.EndpointURL
+}
+
+// validateEndpointURL returns an error if the supplied string is not an
+// absolute URL that an AWS service API client can call
+func validateEndpointURL(endpointURL string) error {
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("endpoint URL %q must include a scheme and a host", endpointURL)
+	}
 	return nil
 }
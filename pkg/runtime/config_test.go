@@ -0,0 +1,193 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+func TestConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	validCfg := func() ackrt.Config {
+		return ackrt.Config{
+			AccountID: "123456789012",
+			Region:    "us-west-2",
+		}
+	}
+
+	cfg := validCfg()
+	require.Nil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.EndpointURL = "http://localhost:4566"
+	cfg.ServiceEndpointURLs = []string{"s3=http://localhost:9000"}
+	require.Nil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.EndpointURL = "localhost:4566"
+	require.NotNil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.ServiceEndpointURLs = []string{"s3"}
+	require.NotNil(cfg.Validate())
+
+	for _, provider := range []string{
+		"default",
+		"secret:aws-credentials",
+		"secret:ack-system/aws-credentials",
+		"profile:dev",
+		"web-identity:arn:aws:iam::123456789012:role/ack",
+	} {
+		cfg = validCfg()
+		cfg.CredentialsProvider = provider
+		require.Nil(cfg.Validate(), provider)
+	}
+
+	for _, provider := range []string{
+		"default:foo",
+		"secret",
+		"secret:ack-system/",
+		"profile",
+		"web-identity:",
+		"environment",
+	} {
+		cfg = validCfg()
+		cfg.CredentialsProvider = provider
+		require.NotNil(cfg.Validate(), provider)
+	}
+
+	cfg = validCfg()
+	cfg.ClusterID = "prod-us-west-2"
+	cfg.OrphanScanPeriod.Duration = time.Hour
+	cfg.OrphanGracePeriod.Duration = 24 * time.Hour
+	require.Nil(cfg.Validate())
+
+	// The orphan scan only considers the resources tagged with the
+	// cluster's ID
+	cfg = validCfg()
+	cfg.OrphanScanPeriod.Duration = time.Hour
+	require.NotNil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.OrphanGracePeriod.Duration = 24 * time.Hour
+	require.NotNil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.OrphanGracePeriod.Duration = -time.Hour
+	require.NotNil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.DiscoveryTags = []string{"team=storage", "env=production"}
+	require.Nil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.DiscoveryTags = []string{"team"}
+	require.NotNil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.EventQueueURL = "http://localhost:9324/queue/ack-events"
+	require.Nil(cfg.Validate())
+
+	cfg = validCfg()
+	cfg.EventQueueURL = "ack-events"
+	require.NotNil(cfg.Validate())
+}
+
+func TestConfigValidateResources(t *testing.T) {
+	require := require.New(t)
+
+	gks := []*metav1.GroupKind{
+		{Group: "ecr.services.k8s.aws", Kind: "Repository"},
+		{Group: "sns.services.k8s.aws", Kind: "Topic"},
+	}
+
+	cfg := ackrt.Config{}
+	require.Nil(cfg.ValidateResources(gks))
+
+	cfg.EnableResources = []string{"repository", "Topic.sns.services.k8s.aws"}
+	cfg.DisableResources = []string{"Topic"}
+	require.Nil(cfg.ValidateResources(gks))
+
+	// A typo would otherwise silently disable every kind of resource
+	cfg.EnableResources = []string{"Repository", "Repositroy"}
+	require.EqualError(
+		cfg.ValidateResources(gks),
+		"invalid value for --enable-resources flag: Repositroy matches no kind of resource managed by the service controllers",
+	)
+
+	cfg.EnableResources = nil
+	cfg.DisableResources = []string{"Topic.ecr.services.k8s.aws"}
+	require.NotNil(cfg.ValidateResources(gks))
+}
+
+func TestConfigLoad(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "ack-config")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.yaml")
+	data := `
+awsRegion: eu-west-1
+logLevel: debug
+resyncPeriod: 10m
+resources:
+  Repository.ecr.services.k8s.aws:
+    resyncPeriod: 1h
+    reconcileRateLimit: 1
+`
+	require.Nil(ioutil.WriteFile(path, []byte(data), 0600))
+
+	cfg := ackrt.Config{
+		AccountID:          "123456789012",
+		Region:             "us-west-2",
+		LogLevel:           "info",
+		ReconcileRateLimit: 10,
+		ReconcileRateBurst: 100,
+		ConfigFile:         path,
+	}
+	require.Nil(cfg.Load())
+	require.Nil(cfg.Validate())
+
+	// Values in the configuration file take precedence over the flags
+	require.Equal("123456789012", cfg.AccountID)
+	require.Equal("eu-west-1", cfg.Region)
+	require.Equal("debug", cfg.LogLevel)
+	require.Equal(10*time.Minute, cfg.ResyncPeriod.Duration)
+	require.Equal(float64(10), cfg.ReconcileRateLimit)
+
+	resCfg, found := cfg.Resources["Repository.ecr.services.k8s.aws"]
+	require.True(found)
+	require.Equal(time.Hour, resCfg.ResyncPeriod.Duration)
+	require.Equal(float64(1), *resCfg.ReconcileRateLimit)
+	require.Nil(resCfg.ReconcileRateBurst)
+
+	cfg.ConfigFile = filepath.Join(dir, "missing.yaml")
+	require.NotNil(cfg.Load())
+
+	require.Nil(ioutil.WriteFile(path, []byte("resyncPeriod: often"), 0600))
+	cfg.ConfigFile = path
+	require.NotNil(cfg.Load())
+}
@@ -0,0 +1,239 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackrtcache "github.com/aws/aws-controllers-k8s/pkg/runtime/cache"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// CredentialsProviderDefault is the name of the credentials provider that
+	// uses the default AWS credential chain of the service controller
+	CredentialsProviderDefault = "default"
+	// CredentialsProviderSecret is the name of the credentials provider that
+	// uses a static access key stored in a Kubernetes Secret
+	CredentialsProviderSecret = "secret"
+	// CredentialsProviderProfile is the name of the credentials provider that
+	// uses a named profile from the shared credentials file
+	CredentialsProviderProfile = "profile"
+	// CredentialsProviderWebIdentity is the name of the credentials provider
+	// that assumes an IAM Role using the web identity token file
+	CredentialsProviderWebIdentity = "web-identity"
+)
+
+const (
+	// secretKeyAccessKeyID is the key of the AWS access key ID in a Secret
+	// used by the secret credentials provider
+	secretKeyAccessKeyID = "aws_access_key_id"
+	// secretKeySecretAccessKey is the key of the AWS secret access key in a
+	// Secret used by the secret credentials provider
+	secretKeySecretAccessKey = "aws_secret_access_key"
+	// secretKeySessionToken is the key of the optional AWS session token in a
+	// Secret used by the secret credentials provider
+	secretKeySessionToken = "aws_session_token"
+	// webIdentityRoleSessionName is the name of the IAM Role sessions created
+	// by the web identity credentials provider
+	webIdentityRoleSessionName = "ack-service-controller"
+	// envWebIdentityTokenFile is the environment variable containing the
+	// path to the web identity token file when none is configured
+	envWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+)
+
+// credentialsSource describes where the AWS credentials used to manage a
+// resource come from. It's comparable, so that sessions using the same
+// credentials can be shared.
+type credentialsSource struct {
+	// provider is the name of the credentials provider
+	provider string
+	// secret identifies the Secret containing the static access key used by
+	// the secret credentials provider
+	secret k8stypes.NamespacedName
+	// profile is the name of the profile used by the profile credentials
+	// provider
+	profile string
+	// roleARN is the ARN of the IAM Role assumed by the web identity
+	// credentials provider
+	roleARN string
+}
+
+// parseCredentialsProvider returns the credentialsSource described by the
+// supplied string, in the form `<provider>[:<argument>]`, for a resource in
+// the supplied namespace. See the documentation of the
+// `services.k8s.aws/credentials-provider` annotation for the accepted values.
+func parseCredentialsProvider(
+	desc string,
+	namespace string,
+) (credentialsSource, error) {
+	parts := strings.SplitN(desc, ":", 2)
+	src := credentialsSource{provider: parts[0]}
+	arg := ""
+	if len(parts) == 2 {
+		arg = parts[1]
+	}
+	switch src.provider {
+	case "", CredentialsProviderDefault:
+		src.provider = CredentialsProviderDefault
+		if arg != "" {
+			return src, fmt.Errorf("credentials provider %q takes no argument", CredentialsProviderDefault)
+		}
+	case CredentialsProviderSecret:
+		src.secret.Namespace = namespace
+		src.secret.Name = arg
+		if nsName := strings.SplitN(arg, "/", 2); len(nsName) == 2 {
+			src.secret.Namespace = nsName[0]
+			src.secret.Name = nsName[1]
+		}
+		if src.secret.Name == "" {
+			return src, fmt.Errorf("credentials provider %q requires a Secret name", CredentialsProviderSecret)
+		}
+	case CredentialsProviderProfile:
+		src.profile = arg
+		if src.profile == "" {
+			return src, fmt.Errorf("credentials provider %q requires a profile name", CredentialsProviderProfile)
+		}
+	case CredentialsProviderWebIdentity:
+		src.roleARN = arg
+		if src.roleARN == "" {
+			return src, fmt.Errorf("credentials provider %q requires an IAM Role ARN", CredentialsProviderWebIdentity)
+		}
+	default:
+		return src, fmt.Errorf("unknown credentials provider %q", src.provider)
+	}
+	return src, nil
+}
+
+// getCredentialsSource returns where the AWS credentials used to manage the
+// supplied resource come from. If the resource's namespace has a credentials
+// provider associated with it, it is used, and Secrets without a namespace
+// are in the resource's namespace. Otherwise the credentials provider
+// specified in the configuration is used, and Secrets without a namespace
+// are in the service controller's namespace, so that all the resources
+// managed with the configured credentials use the same Secret.
+func (r *reconciler) getCredentialsSource(
+	res acktypes.AWSResource,
+) (credentialsSource, error) {
+	ns := res.MetaObject().GetNamespace()
+	desc, ok := r.cache.Namespaces.GetCredentialsProvider(ns)
+	if ok {
+		src, err := parseCredentialsProvider(desc, ns)
+		if err != nil {
+			return src, fmt.Errorf(
+				"invalid %s annotation on namespace %s: %v",
+				ackv1alpha1.AnnotationCredentialsProvider, ns, err,
+			)
+		}
+		return src, nil
+	}
+	// Config.Validate() ensures the flag value is well-formed
+	return parseCredentialsProvider(
+		r.cfg.CredentialsProvider, ackrtcache.CurrentNamespace(),
+	)
+}
+
+// newCredentials returns the AWS credentials from the supplied source, or nil
+// if the default AWS credential chain should be used. The supplied session is
+// used to call the AWS STS API when the credentials come from an assumed IAM
+// Role.
+func (r *reconciler) newCredentials(
+	src credentialsSource,
+	sess *session.Session,
+) *credentials.Credentials {
+	switch src.provider {
+	case CredentialsProviderSecret:
+		return credentials.NewCredentials(&secretCredentialsProvider{
+			kc:     r.kc,
+			secret: src.secret,
+		})
+	case CredentialsProviderProfile:
+		return credentials.NewSharedCredentials(
+			r.cfg.CredentialsFile, src.profile,
+		)
+	case CredentialsProviderWebIdentity:
+		tokenFile := r.cfg.WebIdentityTokenFile
+		if tokenFile == "" {
+			tokenFile = os.Getenv(envWebIdentityTokenFile)
+		}
+		return stscreds.NewWebIdentityCredentials(
+			sess, src.roleARN, webIdentityRoleSessionName, tokenFile,
+		)
+	}
+	return nil
+}
+
+// secretCredentialsProvider is a `credentials.Provider` that retrieves a
+// static access key from a Kubernetes Secret. The credentials expire whenever
+// the Secret changes, so that AWS service API clients pick up the new access
+// key.
+type secretCredentialsProvider struct {
+	kc client.Reader
+	// secret identifies the Secret containing the access key
+	secret k8stypes.NamespacedName
+	// resourceVersion is the resource version of the Secret the current
+	// credentials were retrieved from
+	resourceVersion string
+}
+
+// getSecret returns the Secret containing the access key
+func (p *secretCredentialsProvider) getSecret() (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := p.kc.Get(context.TODO(), p.secret, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Retrieve implements `credentials.Provider` and returns the access key in the
+// Secret
+func (p *secretCredentialsProvider) Retrieve() (credentials.Value, error) {
+	val := credentials.Value{ProviderName: "SecretCredentialsProvider"}
+	secret, err := p.getSecret()
+	if err != nil {
+		return val, err
+	}
+	val.AccessKeyID = string(secret.Data[secretKeyAccessKeyID])
+	val.SecretAccessKey = string(secret.Data[secretKeySecretAccessKey])
+	val.SessionToken = string(secret.Data[secretKeySessionToken])
+	if val.AccessKeyID == "" || val.SecretAccessKey == "" {
+		return val, fmt.Errorf(
+			"Secret %s must contain the %s and %s keys",
+			p.secret, secretKeyAccessKeyID, secretKeySecretAccessKey,
+		)
+	}
+	p.resourceVersion = secret.ResourceVersion
+	return val, nil
+}
+
+// IsExpired implements `credentials.Provider` and returns true if the Secret
+// has changed since the credentials were retrieved
+func (p *secretCredentialsProvider) IsExpired() bool {
+	secret, err := p.getSecret()
+	if err != nil {
+		return true
+	}
+	return secret.ResourceVersion != p.resourceVersion
+}
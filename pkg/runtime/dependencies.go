@@ -0,0 +1,241 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// eventReasonWaitingForDependencies is the reason of the event emitted
+	// when the creation or update of a resource is held off until the
+	// resources it depends on are synced
+	eventReasonWaitingForDependencies = "WaitingForDependencies"
+	// eventReasonInvalidDependencies is the reason of the event emitted
+	// when the services.k8s.aws/depends-on annotation of a resource is
+	// invalid
+	eventReasonInvalidDependencies = "InvalidDependencies"
+)
+
+// Dependency identifies a CR listed in the services.k8s.aws/depends-on
+// annotation of another CR
+type Dependency struct {
+	// Kind is the kind of the CR, e.g. "Deployment"
+	Kind string
+	// Name is the name of the CR, which is in the namespace of the CR that
+	// depends on it
+	Name string
+}
+
+// String returns the dependency in the form <kind>/<name>
+func (d Dependency) String() string {
+	return d.Kind + "/" + d.Name
+}
+
+// ParseDependencies returns the dependencies in the supplied value of the
+// services.k8s.aws/depends-on annotation, a comma-separated list of
+// <kind>/<name> entries
+func ParseDependencies(value string) ([]Dependency, error) {
+	deps := []Dependency{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%q is not in the form kind/name", entry)
+		}
+		deps = append(deps, Dependency{Kind: parts[0], Name: parts[1]})
+	}
+	return deps, nil
+}
+
+// unsyncedDependencies returns the dependencies listed in the
+// services.k8s.aws/depends-on annotation of the supplied resource whose CR
+// doesn't exist or isn't synced. An error wrapping ackerr.InvalidDependencies
+// is returned if the annotation is malformed or lists a dependency of a kind
+// that the service controller doesn't manage, since such a dependency would
+// never be synced.
+func (r *reconciler) unsyncedDependencies(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) ([]Dependency, error) {
+	mo := res.MetaObject()
+	value, ok := mo.GetAnnotations()[ackv1alpha1.AnnotationDependsOn]
+	if !ok {
+		return nil, nil
+	}
+	deps, err := ParseDependencies(value)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: %s annotation: %v", ackerr.InvalidDependencies,
+			ackv1alpha1.AnnotationDependsOn, err,
+		)
+	}
+	unsynced := []Dependency{}
+	for _, dep := range deps {
+		rd, found := r.related[dep.Kind]
+		if !found {
+			return nil, fmt.Errorf(
+				"%w: %s is not of a kind managed by the service controller",
+				ackerr.InvalidDependencies, dep.String(),
+			)
+		}
+		ro := rd.EmptyRuntimeObject()
+		err = r.kc.Get(ctx, k8stypes.NamespacedName{
+			Namespace: mo.GetNamespace(),
+			Name:      dep.Name,
+		}, ro)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				unsynced = append(unsynced, dep)
+				continue
+			}
+			return nil, err
+		}
+		if !IsSynced(rd.ResourceFromRuntimeObject(ro)) {
+			unsynced = append(unsynced, dep)
+		}
+	}
+	return unsynced, nil
+}
+
+// setDependencyConditions sets the ACK.WaitingForDependencies condition of
+// the supplied resource to True, and its ACK.ResourceSynced condition to
+// False, if the supplied dependencies that the creation or update of the
+// resource waits for aren't empty. Otherwise the ACK.WaitingForDependencies
+// condition, if any, is set to False.
+func (r *reconciler) setDependencyConditions(
+	res acktypes.AWSResource,
+	waitingFor []Dependency,
+) {
+	if len(waitingFor) == 0 {
+		if GetCondition(res, ackv1alpha1.ConditionTypeWaitingForDependencies) != nil {
+			SetCondition(
+				res, ackv1alpha1.ConditionTypeWaitingForDependencies,
+				corev1.ConditionFalse, "",
+			)
+		}
+		return
+	}
+	names := make([]string, 0, len(waitingFor))
+	for _, dep := range waitingFor {
+		names = append(names, dep.String())
+	}
+	msg := "waiting for " + strings.Join(names, ", ") + " to be synced"
+	wasWaiting := isWaitingForDependencies(res)
+	SetCondition(
+		res, ackv1alpha1.ConditionTypeWaitingForDependencies,
+		corev1.ConditionTrue, msg,
+	)
+	SetCondition(
+		res, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionFalse, msg,
+	)
+	if !wasWaiting {
+		r.log.V(0).Info("waiting for dependencies", "dependencies", names)
+		r.recordEvent(
+			res, corev1.EventTypeNormal, eventReasonWaitingForDependencies,
+			"The AWS resource won't be created or updated until "+
+				strings.Join(names, ", ")+" are synced",
+		)
+	}
+}
+
+// handleDependencyError sets the ACK.Terminal condition of the supplied
+// resource if the supplied error is an invalid dependencies error, since the
+// resource can't be synced until its services.k8s.aws/depends-on annotation
+// is fixed, and returns any other error. The error is only logged and
+// recorded as an event when the condition changes.
+func (r *reconciler) handleDependencyError(
+	ctx context.Context,
+	current acktypes.AWSResource,
+	err error,
+) error {
+	if !errors.Is(err, ackerr.InvalidDependencies) {
+		return err
+	}
+	latest := r.rd.ResourceFromRuntimeObject(
+		current.RuntimeObject().DeepCopyObject(),
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeTerminal,
+		corev1.ConditionTrue, err.Error(),
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionFalse, err.Error(),
+	)
+	if r.rd.Equal(current, latest) {
+		return nil
+	}
+	r.log.V(0).Info("invalid dependencies", "reason", err.Error())
+	r.recordEvent(
+		latest, corev1.EventTypeWarning, eventReasonInvalidDependencies,
+		err.Error(),
+	)
+	return r.patchResourceStatus(ctx, current, latest)
+}
+
+// waitForDependencies holds off the creation of the backend AWS service API
+// resource for the supplied resource until the supplied dependencies are
+// synced, recording them in the resource's conditions
+func (r *reconciler) waitForDependencies(
+	ctx context.Context,
+	current acktypes.AWSResource,
+	waitingFor []Dependency,
+) error {
+	latest := r.rd.ResourceFromRuntimeObject(
+		current.RuntimeObject().DeepCopyObject(),
+	)
+	r.setDependencyConditions(latest, waitingFor)
+	if !r.rd.Equal(current, latest) {
+		if err := r.patchResourceStatus(ctx, current, latest); err != nil {
+			return err
+		}
+	}
+	return requeueIfWaiting(latest)
+}
+
+// requeueIfWaiting returns an error instructing the controller-runtime to
+// requeue the supplied resource if it's waiting for the resources it depends
+// on to be synced, or else the result of requeueIfNotStable
+func requeueIfWaiting(res acktypes.AWSResource) error {
+	if !isWaitingForDependencies(res) {
+		return requeueIfNotStable(res)
+	}
+	return requeue.NeededWithBackoff(
+		ackerr.DependenciesNotSynced, requeue.DefaultBackoff,
+	)
+}
+
+// isWaitingForDependencies returns true if the supplied resource has an
+// ACK.WaitingForDependencies condition with a True status
+func isWaitingForDependencies(res acktypes.AWSResource) bool {
+	waiting := GetCondition(res, ackv1alpha1.ConditionTypeWaitingForDependencies)
+	return waiting != nil && waiting.Status == corev1.ConditionTrue
+}
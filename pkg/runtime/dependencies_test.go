@@ -0,0 +1,50 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+func TestParseDependencies(t *testing.T) {
+	require := require.New(t)
+
+	deps, err := ackrt.ParseDependencies(
+		"Deployment/my-deployment, CacheSubnetGroup/my-subnet-group,",
+	)
+	require.Nil(err)
+	require.Equal([]ackrt.Dependency{
+		{Kind: "Deployment", Name: "my-deployment"},
+		{Kind: "CacheSubnetGroup", Name: "my-subnet-group"},
+	}, deps)
+	require.Equal("Deployment/my-deployment", deps[0].String())
+
+	deps, err = ackrt.ParseDependencies("")
+	require.Nil(err)
+	require.Empty(deps)
+
+	for _, value := range []string{
+		"my-deployment",
+		"Deployment/",
+		"/my-deployment",
+		"Deployment/default/my-deployment",
+	} {
+		_, err = ackrt.ParseDependencies(value)
+		require.NotNil(err, value)
+	}
+}
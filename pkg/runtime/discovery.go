@@ -0,0 +1,259 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// maxCRNameLength is the maximum length of the name of a CR
+	maxCRNameLength = 253
+)
+
+// DiscoveryFilter selects the existing AWS resources that discovery creates
+// CRs for
+type DiscoveryFilter struct {
+	// NamePrefix is the prefix the names of the resources must start with
+	NamePrefix string
+	// Tags are the tags the resources must all have
+	Tags map[string]string
+}
+
+// Matches returns true if the supplied resource, whose name is supplied, is
+// selected by the DiscoveryFilter. Resources that can't have tags are only
+// selected if the DiscoveryFilter has no tags.
+func (f DiscoveryFilter) Matches(res acktypes.AWSResource, name string) bool {
+	if !strings.HasPrefix(name, f.NamePrefix) {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+	taggable, ok := res.(acktypes.TaggableAWSResource)
+	if !ok {
+		return false
+	}
+	tags := taggable.GetTags()
+	for k, v := range f.Tags {
+		if tv, found := tags[k]; !found || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// discoverer creates CRs for the existing resources of a single kind in the
+// backend AWS service API, so that the service controller adopts them. It
+// lists the resources in the service controller's AWS account and region
+// once, when the service controller starts, and creates a CR in the discovery
+// namespace for each resource selected by the discovery filter. The CRs are
+// created from the state of the resources returned by ReadOne, since List
+// operations often return only some of their fields. The CRs have
+// the ARN annotation set, which makes the service controller adopt the
+// resource instead of creating a new one.
+//
+// Resources that have the ownership tags the service controller adds to the
+// resources it manages already have a CR, or are orphaned, and are skipped.
+// Resources whose CR name is already taken are skipped too. Only kinds of
+// resources whose resource manager implements AWSResourceLister and whose
+// resources implement NamedAWSResource can be discovered.
+//
+// discoverer implements the upstream controller-runtime `manager.Runnable`
+// interface.
+type discoverer struct {
+	r      *reconciler
+	log    logr.Logger
+	cfg    Config
+	filter DiscoveryFilter
+}
+
+// newDiscoverer returns a discoverer for the kind of resource reconciled by the
+// supplied reconciler
+func newDiscoverer(r *reconciler) *discoverer {
+	return &discoverer{
+		r:      r,
+		log:    r.log.WithName("discovery").WithValues("kind", r.rd.GroupKind().String()),
+		cfg:    r.cfg,
+		filter: r.cfg.discoveryFilter(),
+	}
+}
+
+// NeedLeaderElection implements the upstream controller-runtime
+// `manager.LeaderElectionRunnable` interface. Only the leader discovers
+// resources, so that their CRs are created once.
+func (d *discoverer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the upstream controller-runtime `manager.Runnable`
+// interface, discovering the resources once
+func (d *discoverer) Start(stopCh <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := d.discover(ctx); err != nil {
+		d.log.Error(err, "unable to discover resources")
+	}
+	return nil
+}
+
+// discover lists the resources in the backend AWS service API, reads each of
+// the resources whose name is selected by the discovery filter, and creates a
+// CR for each of the resources selected by the discovery filter
+func (d *discoverer) discover(ctx context.Context) error {
+	rm, err := d.r.defaultResourceManager(d.cfg)
+	if err != nil {
+		return err
+	}
+	lister, ok := rm.(acktypes.AWSResourceLister)
+	if !ok {
+		d.log.Info("resource manager can't list resources, skipping discovery")
+		return nil
+	}
+	resources, err := lister.ReadMany(ctx)
+	if err != nil {
+		return ackerr.Wrap(err, d.r.rd.GroupKind(), "ReadMany")
+	}
+	created := 0
+	for _, res := range resources {
+		named, ok := res.(acktypes.NamedAWSResource)
+		if !ok {
+			d.log.Info("resources have no name, skipping discovery")
+			return nil
+		}
+		arn := res.Identifiers().ARN()
+		name := named.ResourceName()
+		if name == "" && arn != nil {
+			// Some List operations only return the ARNs of the resources
+			name = nameFromARN(*arn)
+			named.SetResourceName(name)
+		}
+		if name == "" || !strings.HasPrefix(name, d.filter.NamePrefix) {
+			continue
+		}
+		// Some List operations only return some of the fields of the
+		// resources. The CR is created from the full state of the resource,
+		// or else the first sync would update the resource to match the
+		// fields missing from the CR's Spec.
+		res, err = rm.ReadOne(ctx, res)
+		if err != nil {
+			if !ackerr.IsNotFound(err) {
+				d.log.Error(
+					ackerr.Wrap(err, d.r.rd.GroupKind(), "ReadOne"),
+					"unable to read resource", "name", name,
+				)
+			}
+			continue
+		}
+		if _, managed := TaggedOwner(res); managed {
+			continue
+		}
+		if !d.filter.Matches(res, name) {
+			continue
+		}
+		if arn == nil {
+			arn = res.Identifiers().ARN()
+		}
+		if arn == nil {
+			tmpARN := ackv1alpha1.AWSResourceName(rm.ARNFromName(name))
+			if tmpARN == "" {
+				d.log.Info(
+					"unable to determine ARN of resource, skipping",
+					"name", name,
+				)
+				continue
+			}
+			arn = &tmpARN
+		}
+		crName := crNameFromResourceName(name)
+		if crName == "" {
+			d.log.Info(
+				"unable to name CR for resource, skipping",
+				"arn", *arn,
+			)
+			continue
+		}
+		mo := res.MetaObject()
+		mo.SetNamespace(d.cfg.DiscoveryNamespace)
+		mo.SetName(crName)
+		mo.SetAnnotations(map[string]string{
+			ackv1alpha1.AnnotationARN: string(*arn),
+		})
+		err = d.r.kc.Create(ctx, res.RuntimeObject())
+		if err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				d.log.V(1).Info(
+					"CR already exists, skipping resource",
+					"arn", *arn,
+					"name", crName,
+				)
+				continue
+			}
+			d.log.Error(err, "unable to create CR for resource", "arn", *arn)
+			continue
+		}
+		d.log.V(1).Info("created CR for resource", "arn", *arn, "name", crName)
+		created++
+	}
+	d.log.Info(
+		"discovered resources",
+		"namespace", d.cfg.DiscoveryNamespace,
+		"listed", len(resources),
+		"created", created,
+	)
+	return nil
+}
+
+// nameFromARN returns the last part of the resource identifier in the
+// supplied ARN, e.g. "my-topic" for "arn:aws:sns:us-west-2:123456789012:my-topic"
+func nameFromARN(arn ackv1alpha1.AWSResourceName) string {
+	parsed, err := ackarn.Parse(arn)
+	if err != nil {
+		return ""
+	}
+	idx := strings.LastIndexAny(parsed.Resource, ":/")
+	return parsed.Resource[idx+1:]
+}
+
+// crNameFromResourceName returns a valid CR name, i.e. a DNS subdomain, for
+// the AWS resource with the supplied name. Upper case letters are lowered and
+// other characters that aren't allowed are replaced with a hyphen.
+func crNameFromResourceName(name string) string {
+	res := []rune(strings.ToLower(name))
+	for i, c := range res {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '.' && c != '-' {
+			res[i] = '-'
+		}
+	}
+	if len(res) > maxCRNameLength {
+		res = res[:maxCRNameLength]
+	}
+	return strings.Trim(string(res), "-.")
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestDiscoveryFilter(t *testing.T) {
+	require := require.New(t)
+
+	res := &mocks.TaggableAWSResource{}
+	res.On("GetTags").Return(map[string]string{
+		"team": "storage",
+		"env":  "production",
+	})
+	untaggedRes := &mocks.AWSResource{}
+
+	filter := ackrt.DiscoveryFilter{}
+	require.True(filter.Matches(res, "my-repo"))
+	require.True(filter.Matches(untaggedRes, "my-repo"))
+
+	filter = ackrt.DiscoveryFilter{NamePrefix: "my-"}
+	require.True(filter.Matches(res, "my-repo"))
+	require.False(filter.Matches(res, "other-repo"))
+
+	filter = ackrt.DiscoveryFilter{
+		NamePrefix: "my-",
+		Tags:       map[string]string{"team": "storage"},
+	}
+	require.True(filter.Matches(res, "my-repo"))
+	require.False(filter.Matches(res, "other-repo"))
+	require.False(filter.Matches(untaggedRes, "my-repo"))
+
+	filter = ackrt.DiscoveryFilter{
+		Tags: map[string]string{"team": "storage", "env": "staging"},
+	}
+	require.False(filter.Matches(res, "my-repo"))
+}
@@ -0,0 +1,146 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// eventReasonDriftDetected is the reason of the event emitted when a
+	// resource whose drift policy is report is found to have drifted from its
+	// desired state
+	eventReasonDriftDetected = "DriftDetected"
+	// eventReasonDriftResolved is the reason of the event emitted when a
+	// resource that had drifted from its desired state matches it again
+	eventReasonDriftResolved = "DriftResolved"
+)
+
+// DriftedPaths returns the sorted, unique paths of the fields of the Spec,
+// e.g. "Spec.ImageTagMutability", that differ in the supplied Reporter
+func DriftedPaths(diff *ackcompare.Reporter) []string {
+	paths := []string{}
+	seen := map[string]bool{}
+	for _, d := range diff.Differences {
+		if d.Path != "Spec" && !strings.HasPrefix(d.Path, "Spec.") {
+			continue
+		}
+		if !seen[d.Path] {
+			seen[d.Path] = true
+			paths = append(paths, d.Path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// driftPolicy returns the drift policy of the supplied resource: the value of
+// the services.k8s.aws/drift-policy annotation on the resource or, if it has
+// none, on its namespace. Invalid values are ignored and the default policy
+// is enforce.
+func (r *reconciler) driftPolicy(res acktypes.AWSResource) string {
+	mo := res.MetaObject()
+	policy, ok := mo.GetAnnotations()[ackv1alpha1.AnnotationDriftPolicy]
+	if !ok {
+		policy, ok = r.cache.Namespaces.GetDriftPolicy(mo.GetNamespace())
+	}
+	if !ok {
+		return ackv1alpha1.DriftPolicyEnforce
+	}
+	switch policy {
+	case ackv1alpha1.DriftPolicyEnforce, ackv1alpha1.DriftPolicyReport:
+		return policy
+	}
+	r.log.Info(
+		"ignoring invalid drift policy",
+		"annotation", ackv1alpha1.AnnotationDriftPolicy,
+		"value", policy,
+	)
+	return ackv1alpha1.DriftPolicyEnforce
+}
+
+// setDriftConditions sets the ACK.Drifted condition of the supplied latest
+// resource to True, and its ACK.ResourceSynced condition to False, if the
+// supplied paths of the fields that drifted from the desired state aren't
+// empty. Otherwise the ACK.Drifted condition, if any, is set to False once
+// the resource is stable. Events are emitted, and metrics updated, when the
+// resource starts or stops drifting.
+func (r *reconciler) setDriftConditions(
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	driftedPaths []string,
+) {
+	wasDrifted := isDrifted(desired)
+	if len(driftedPaths) == 0 {
+		// Resources that aren't stable aren't compared to the desired state
+		if !isStable(latest) ||
+			GetCondition(latest, ackv1alpha1.ConditionTypeDrifted) == nil {
+			return
+		}
+		SetCondition(
+			latest, ackv1alpha1.ConditionTypeDrifted,
+			corev1.ConditionFalse, "",
+		)
+		if wasDrifted {
+			r.log.V(0).Info("resource no longer drifts from desired state")
+			r.recordEvent(
+				latest, corev1.EventTypeNormal, eventReasonDriftResolved,
+				"The AWS resource matches the desired state again",
+			)
+		}
+		return
+	}
+	msg := "resource differs from the desired state at " +
+		strings.Join(driftedPaths, ", ")
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeDrifted,
+		corev1.ConditionTrue, msg,
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionFalse,
+		"resource has drifted from the desired state and the drift policy is "+
+			ackv1alpha1.DriftPolicyReport,
+	)
+	if !wasDrifted {
+		gk := r.rd.GroupKind()
+		driftDetected.WithLabelValues(gk.Group, gk.Kind).Inc()
+		r.log.V(0).Info(
+			"resource drifted from desired state",
+			"paths", driftedPaths,
+			"arn", latest.Identifiers().ARN(),
+		)
+		r.recordEvent(
+			latest, corev1.EventTypeWarning, eventReasonDriftDetected,
+			"The AWS resource differs from the desired state at "+
+				strings.Join(driftedPaths, ", ")+
+				"; it won't be updated because the drift policy is "+
+				ackv1alpha1.DriftPolicyReport,
+		)
+	}
+}
+
+// isDrifted returns true if the supplied resource has an ACK.Drifted
+// condition with a True status
+func isDrifted(res acktypes.AWSResource) bool {
+	drifted := GetCondition(res, ackv1alpha1.ConditionTypeDrifted)
+	return drifted != nil && drifted.Status == corev1.ConditionTrue
+}
@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+func TestDriftedPaths(t *testing.T) {
+	require := require.New(t)
+
+	diff := &ackcompare.Reporter{
+		Differences: []ackcompare.DiffItem{
+			{Path: "Spec.Tags.Value", ValueA: "storage", ValueB: "compute"},
+			{Path: "Status.Conditions", ValueA: "[]", ValueB: "[...]"},
+			{Path: "Spec.ImageTagMutability", ValueA: "IMMUTABLE", ValueB: "MUTABLE"},
+			{Path: "Spec.Tags.Value", ValueA: "dev", ValueB: "prod"},
+			{Path: "Specification", ValueA: "a", ValueB: "b"},
+		},
+	}
+	require.Equal(
+		[]string{"Spec.ImageTagMutability", "Spec.Tags.Value"},
+		ackrt.DriftedPaths(diff),
+	)
+	require.Empty(ackrt.DriftedPaths(&ackcompare.Reporter{}))
+}
@@ -0,0 +1,356 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
+)
+
+const (
+	// arnIndexField is the name of the field index of CRs by the ARN of
+	// their backend AWS service API resource
+	arnIndexField = "ack.arn"
+	// eventQueueBatchSize is the maximum number of notifications received
+	// from the event queue at once
+	eventQueueBatchSize = 10
+	// eventQueueWaitSeconds is how long, in seconds, to wait for
+	// notifications to arrive in the event queue before receiving again
+	eventQueueWaitSeconds = 20
+	// eventQueueRetryDelay is how long to wait before receiving
+	// notifications again after failing to receive them
+	eventQueueRetryDelay = 10 * time.Second
+)
+
+// eventTarget is a kind of resource whose CRs are reconciled when
+// notifications about their backend AWS service API resources are received
+type eventTarget struct {
+	gk    string
+	group string
+	kind  string
+	index *resourceIndex
+	// emptyObject returns an empty CR of the kind of resource
+	emptyObject func() k8sruntime.Object
+	// events is the source of the events enqueueing the CRs in the
+	// controller of the kind of resource
+	events chan event.GenericEvent
+}
+
+// eventSource receives AWS resource change notifications, e.g. CloudTrail
+// events delivered by EventBridge, from an SQS queue and reconciles the CRs of
+// the resources named by the notifications right away, instead of waiting for
+// the next resync. CRs are found by the ARNs in the notifications using a field
+// index of the CRs of each kind by the ARN of their backend AWS service API
+// resource.
+//
+// A single eventSource receives the notifications for all the kinds of
+// resources of the service controllers hosted by a controller manager, so that
+// they don't compete for the notifications. Notifications are deleted from the
+// queue once the CRs they name are enqueued, including notifications naming no
+// CR.
+//
+// eventSource implements the upstream controller-runtime `manager.Runnable`
+// interface.
+type eventSource struct {
+	sqsapi   sqsiface.SQSAPI
+	queueURL string
+	log      logr.Logger
+
+	targetsLock sync.RWMutex
+	targets     []*eventTarget
+}
+
+// newEventSource returns an eventSource receiving notifications from the SQS
+// queue with the URL in the supplied Config
+func newEventSource(cfg Config, log logr.Logger) (*eventSource, error) {
+	region := queueRegion(cfg.EventQueueURL, cfg.Region)
+	sess, err := NewSession(
+		ackv1alpha1.AWSRegion(region), cfg.serviceEndpointURL("sqs"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &eventSource{
+		sqsapi:   sqs.New(sess),
+		queueURL: cfg.EventQueueURL,
+		log:      log.WithName("events"),
+	}, nil
+}
+
+// queueRegion returns the AWS region of the SQS queue with the supplied URL,
+// e.g. https://sqs.us-west-2.amazonaws.com/123456789012/ack-events, or the
+// supplied default region if the URL doesn't contain one, e.g. the URL of a
+// queue in a local stand-in for SQS
+func queueRegion(queueURL string, defaultRegion string) string {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return defaultRegion
+	}
+	labels := strings.Split(u.Hostname(), ".")
+	if len(labels) >= 4 && labels[0] == "sqs" && labels[2] == "amazonaws" {
+		return labels[1]
+	}
+	return defaultRegion
+}
+
+// NeedLeaderElection implements the upstream controller-runtime
+// `manager.LeaderElectionRunnable` interface. Only the leader, whose
+// controllers reconcile the CRs, receives the notifications.
+func (s *eventSource) NeedLeaderElection() bool {
+	return true
+}
+
+// watch indexes the CRs reconciled by the supplied reconciler by the ARN of
+// their backend AWS service API resource, and returns the source of the events
+// enqueueing the CRs named by notifications
+func (s *eventSource) watch(
+	mgr ctrlrt.Manager,
+	r *reconciler,
+) (source.Source, error) {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		r.rd.EmptyRuntimeObject(),
+		arnIndexField,
+		r.arnIndexValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+	index, err := newResourceIndex(
+		mgr.GetClient(), mgr.GetScheme(), r.rd.EmptyRuntimeObject(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	gk := r.rd.GroupKind()
+	target := &eventTarget{
+		gk:          gk.String(),
+		group:       gk.Group,
+		kind:        gk.Kind,
+		index:       index,
+		emptyObject: r.rd.EmptyRuntimeObject,
+		events:      make(chan event.GenericEvent),
+	}
+	s.targetsLock.Lock()
+	defer s.targetsLock.Unlock()
+	s.targets = append(s.targets, target)
+	return &source.Channel{Source: target.events}, nil
+}
+
+// getTargets returns the kinds of resources whose CRs are reconciled when
+// notifications are received
+func (s *eventSource) getTargets() []*eventTarget {
+	s.targetsLock.RLock()
+	defer s.targetsLock.RUnlock()
+	return s.targets
+}
+
+// Start implements the upstream controller-runtime `manager.Runnable`
+// interface, receiving notifications from the queue until the supplied
+// channel is closed
+func (s *eventSource) Start(stopCh <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	s.log.Info("receiving resource change notifications", "queue", s.queueURL)
+	for ctx.Err() == nil {
+		if err := s.receive(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error(
+				err, "unable to receive resource change notifications",
+				"queue", s.queueURL,
+			)
+			select {
+			case <-ctx.Done():
+			case <-time.After(eventQueueRetryDelay):
+			}
+		}
+	}
+	return nil
+}
+
+// receive waits for notifications to arrive in the queue and enqueues the CRs
+// they name. Notifications are deleted from the queue once the CRs they name
+// are enqueued, including notifications naming no CR. Notifications are left
+// in the queue, and received again once their visibility timeout expires, if
+// looking up or enqueueing their CRs fails.
+func (s *eventSource) receive(ctx context.Context) error {
+	resp, err := s.sqsapi.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(s.queueURL),
+		MaxNumberOfMessages: aws.Int64(eventQueueBatchSize),
+		WaitTimeSeconds:     aws.Int64(eventQueueWaitSeconds),
+	})
+	if err != nil {
+		return err
+	}
+	for _, msg := range resp.Messages {
+		if err = s.dispatch(ctx, aws.StringValue(msg.Body)); err != nil {
+			s.log.Error(
+				err, "unable to enqueue resources named by notification",
+				"message_id", aws.StringValue(msg.MessageId),
+			)
+			continue
+		}
+		_, err = s.sqsapi.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(s.queueURL),
+			ReceiptHandle: msg.ReceiptHandle,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch enqueues the CRs of the resources named by the supplied
+// notification
+func (s *eventSource) dispatch(ctx context.Context, body string) error {
+	arns := ResourceARNsFromEvent([]byte(body))
+	if len(arns) == 0 {
+		s.log.V(1).Info("ignoring notification naming no resource")
+		return nil
+	}
+	for _, arn := range arns {
+		for _, target := range s.getTargets() {
+			names, err := target.index.lookup(ctx, arnIndexField, arn)
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				if err = target.enqueue(ctx, name); err != nil {
+					return err
+				}
+				s.log.V(1).Info(
+					"enqueued resource named by notification",
+					"kind", target.gk,
+					"namespace", name.Namespace,
+					"name", name.Name,
+					"arn", arn,
+				)
+				eventReconciles.WithLabelValues(target.group, target.kind).Inc()
+			}
+		}
+	}
+	return nil
+}
+
+// enqueue enqueues the CR with the supplied namespaced name in the controller
+// of the kind of resource
+func (t *eventTarget) enqueue(
+	ctx context.Context,
+	name k8stypes.NamespacedName,
+) error {
+	obj := t.emptyObject()
+	mo, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	mo.SetNamespace(name.Namespace)
+	mo.SetName(name.Name)
+	select {
+	case t.events <- event.GenericEvent{Meta: mo, Object: obj}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// arnIndexValues returns the ARN of the backend AWS service API resource of the
+// supplied CR: the ARN in its Status or, for an adopted resource that hasn't
+// been reconciled yet, the ARN in its adoption annotation
+func (r *reconciler) arnIndexValues(obj k8sruntime.Object) []string {
+	res := r.rd.ResourceFromRuntimeObject(obj)
+	if arn := res.Identifiers().ARN(); arn != nil && *arn != "" {
+		return []string{string(*arn)}
+	}
+	if arn := res.MetaObject().GetAnnotations()[ackv1alpha1.AnnotationARN]; arn != "" {
+		return []string{arn}
+	}
+	return nil
+}
+
+// ResourceARNsFromEvent returns the sorted, unique ARNs of the AWS resources
+// named by the supplied AWS resource change notification: an EventBridge
+// event, e.g. a CloudTrail event delivered by EventBridge, a CloudTrail log
+// containing Records, or either of them wrapped in an SNS notification. The
+// ARNs of the principals making the calls recorded by CloudTrail are ignored.
+func ResourceARNsFromEvent(body []byte) []string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+	if m, ok := doc.(map[string]interface{}); ok && m["Type"] == "Notification" {
+		if msg, ok := m["Message"].(string); ok {
+			return ResourceARNsFromEvent([]byte(msg))
+		}
+	}
+	seen := map[string]bool{}
+	collectARNs(doc, seen)
+	arns := make([]string, 0, len(seen))
+	for arn := range seen {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+	return arns
+}
+
+// collectARNs adds the ARNs in the supplied decoded JSON value to the supplied
+// set
+func collectARNs(val interface{}, arns map[string]bool) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			if key == "userIdentity" {
+				// The principal making the call isn't the resource
+				continue
+			}
+			collectARNs(elem, arns)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			collectARNs(elem, arns)
+		}
+	case string:
+		if !strings.HasPrefix(v, "arn:") {
+			return
+		}
+		if ackarn.Validate(ackv1alpha1.AWSResourceName(v)) == nil {
+			arns[v] = true
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlrtlog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+// cloudTrailEvent is a CloudTrail event delivered by EventBridge
+const cloudTrailEvent = `{
+  "version": "0",
+  "id": "36eb8523-97d0-4518-b33d-ee3579ff19f0",
+  "detail-type": "AWS API Call via CloudTrail",
+  "source": "aws.ecr",
+  "account": "123456789012",
+  "region": "us-west-2",
+  "resources": [],
+  "detail": {
+    "eventVersion": "1.08",
+    "userIdentity": {
+      "type": "AssumedRole",
+      "arn": "arn:aws:sts::123456789012:assumed-role/admin/alice",
+      "sessionContext": {
+        "sessionIssuer": {
+          "arn": "arn:aws:iam::123456789012:role/admin"
+        }
+      }
+    },
+    "eventSource": "ecr.amazonaws.com",
+    "eventName": "PutImageTagMutability",
+    "requestParameters": {
+      "repositoryName": "my-repo",
+      "imageTagMutability": "IMMUTABLE"
+    },
+    "responseElements": {
+      "repositoryName": "my-repo",
+      "imageTagMutability": "IMMUTABLE"
+    },
+    "resources": [
+      {
+        "accountId": "123456789012",
+        "ARN": "arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+      }
+    ]
+  }
+}`
+
+func TestResourceARNsFromEvent(t *testing.T) {
+	require := require.New(t)
+
+	repoARN := "arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+
+	// The ARNs of the principal making the call are ignored
+	require.Equal([]string{repoARN}, ackrt.ResourceARNsFromEvent([]byte(cloudTrailEvent)))
+
+	// EventBridge events name resources in their resources field
+	require.Equal(
+		[]string{repoARN, "arn:aws:sns:us-west-2:123456789012:my-topic"},
+		ackrt.ResourceARNsFromEvent([]byte(`{
+			"detail-type": "Config Configuration Item Change",
+			"resources": [
+				"arn:aws:ecr:us-west-2:123456789012:repository/my-repo",
+				"arn:aws:sns:us-west-2:123456789012:my-topic",
+				"arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+			]
+		}`)),
+	)
+
+	// CloudTrail logs contain Records
+	require.Equal(
+		[]string{repoARN},
+		ackrt.ResourceARNsFromEvent([]byte(`{"Records": [{
+			"userIdentity": {"arn": "arn:aws:iam::123456789012:user/bob"},
+			"responseElements": {"repository": {
+				"repositoryArn": "arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+			}}
+		}]}`)),
+	)
+
+	// Notifications delivered through SNS are unwrapped
+	wrapped, err := json.Marshal(map[string]string{
+		"Type":     "Notification",
+		"TopicArn": "arn:aws:sns:us-west-2:123456789012:ack-events",
+		"Message":  cloudTrailEvent,
+	})
+	require.Nil(err)
+	require.Equal([]string{repoARN}, ackrt.ResourceARNsFromEvent(wrapped))
+
+	// Malformed ARNs and notifications are ignored
+	require.Empty(ackrt.ResourceARNsFromEvent([]byte(`{"resources": ["arn:nope"]}`)))
+	require.Empty(ackrt.ResourceARNsFromEvent([]byte(`not json`)))
+}
+
+// fakeSQS is an SQS API client receiving the supplied messages and recording
+// the receipt handles of the messages deleted
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	messages []*sqs.Message
+	deleted  []string
+}
+
+func (f *fakeSQS) ReceiveMessageWithContext(
+	_ aws.Context,
+	_ *sqs.ReceiveMessageInput,
+	_ ...request.Option,
+) (*sqs.ReceiveMessageOutput, error) {
+	msgs := f.messages
+	f.messages = nil
+	return &sqs.ReceiveMessageOutput{Messages: msgs}, nil
+}
+
+func (f *fakeSQS) DeleteMessageWithContext(
+	_ aws.Context,
+	input *sqs.DeleteMessageInput,
+	_ ...request.Option,
+) (*sqs.DeleteMessageOutput, error) {
+	f.deleted = append(f.deleted, aws.StringValue(input.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+// arnIndex is a client reading ConfigMaps from a field index of ConfigMaps by
+// ARN, and failing to read them for the ARN in failARN
+type arnIndex struct {
+	client.Reader
+	byARN   map[string]corev1.ConfigMap
+	failARN string
+}
+
+func (i *arnIndex) List(
+	_ context.Context,
+	list k8sruntime.Object,
+	opts ...client.ListOption,
+) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	arn, _ := listOpts.FieldSelector.RequiresExactMatch(ackrt.ARNIndexField)
+	if arn == i.failARN {
+		return errors.New("cache not synced")
+	}
+	cms := list.(*corev1.ConfigMapList)
+	if cm, found := i.byARN[arn]; found {
+		cms.Items = append(cms.Items, cm)
+	}
+	return nil
+}
+
+func TestEventSourceReceive(t *testing.T) {
+	require := require.New(t)
+
+	repoARN := "arn:aws:ecr:us-west-2:123456789012:repository/my-repo"
+	otherARN := "arn:aws:ecr:us-west-2:123456789012:repository/other-repo"
+	failARN := "arn:aws:ecr:us-west-2:123456789012:repository/fail-repo"
+
+	sqsapi := &fakeSQS{
+		messages: []*sqs.Message{
+			{
+				// Names the CR of my-repo, which is enqueued
+				ReceiptHandle: aws.String("cr"),
+				Body:          aws.String(cloudTrailEvent),
+			},
+			{
+				// Names a resource with no CR
+				ReceiptHandle: aws.String("no-cr"),
+				Body:          aws.String(`{"resources": ["` + otherARN + `"]}`),
+			},
+			{
+				// Names no resource
+				ReceiptHandle: aws.String("no-resource"),
+				Body:          aws.String(`not json`),
+			},
+			{
+				// Names a resource whose CR can't be looked up
+				ReceiptHandle: aws.String("lookup-error"),
+				Body:          aws.String(`{"resources": ["` + failARN + `"]}`),
+			},
+		},
+	}
+	index := &arnIndex{
+		byARN: map[string]corev1.ConfigMap{
+			repoARN: {ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-repo",
+			}},
+		},
+		failARN: failARN,
+	}
+
+	src := ackrt.NewEventSource(
+		sqsapi,
+		"https://sqs.us-west-2.amazonaws.com/123456789012/ack-events",
+		ctrlrtlog.NullLogger{},
+	)
+	events, err := src.AddTarget(index, clientgoscheme.Scheme, &corev1.ConfigMap{})
+	require.Nil(err)
+
+	received := make(chan error)
+	go func() {
+		received <- src.Receive(context.Background())
+	}()
+
+	var enqueued []event.GenericEvent
+	for done := false; !done; {
+		select {
+		case evt := <-events:
+			enqueued = append(enqueued, evt)
+		case err = <-received:
+			require.Nil(err)
+			done = true
+		}
+	}
+
+	require.Len(enqueued, 1)
+	require.Equal(
+		k8stypes.NamespacedName{Namespace: "default", Name: "my-repo"},
+		k8stypes.NamespacedName{
+			Namespace: enqueued[0].Meta.GetNamespace(),
+			Name:      enqueued[0].Meta.GetName(),
+		},
+	)
+
+	// Notifications whose CRs were enqueued or that name no CR are deleted,
+	// and the notification whose CR couldn't be looked up is left in the
+	// queue to be received again
+	require.Equal([]string{"cr", "no-cr", "no-resource"}, sqsapi.deleted)
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/go-logr/logr"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// This file exports the internals of the package that are exercised by the
+// tests in the runtime_test package.
+
+// ARNIndexField is the name of the field index of CRs by ARN
+const ARNIndexField = arnIndexField
+
+// IsReadOperation returns true if the supplied AWS service API operation only
+// reads resources and isn't audited
+var IsReadOperation = isReadOperation
+
+// EventSource exposes an eventSource to the tests
+type EventSource struct {
+	*eventSource
+}
+
+// NewEventSource returns an EventSource receiving notifications from the SQS
+// queue with the supplied URL using the supplied SQS API client
+func NewEventSource(
+	sqsapi sqsiface.SQSAPI,
+	queueURL string,
+	log logr.Logger,
+) *EventSource {
+	return &EventSource{&eventSource{
+		sqsapi:   sqsapi,
+		queueURL: queueURL,
+		log:      log,
+	}}
+}
+
+// AddTarget adds the kind of the supplied object as a target of the event
+// source, looking up its objects by ARN with the supplied client, and returns
+// the channel of the events enqueueing them
+func (s *EventSource) AddTarget(
+	kc client.Reader,
+	scheme *k8sruntime.Scheme,
+	obj k8sruntime.Object,
+) (<-chan event.GenericEvent, error) {
+	index, err := newResourceIndex(kc, scheme, obj)
+	if err != nil {
+		return nil, err
+	}
+	target := &eventTarget{
+		gk:          "test",
+		index:       index,
+		emptyObject: obj.DeepCopyObject,
+		events:      make(chan event.GenericEvent),
+	}
+	s.targets = append(s.targets, target)
+	return target.events, nil
+}
+
+// Receive receives a single batch of notifications from the queue
+func (s *EventSource) Receive(ctx context.Context) error {
+	return s.receive(ctx)
+}
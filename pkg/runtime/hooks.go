@@ -0,0 +1,111 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+// hookedResourceManager is an AWSResourceManager that calls a set of hooks
+// around the methods of another AWSResourceManager
+type hookedResourceManager struct {
+	acktypes.AWSResourceManager
+	hooks *acktypes.ResourceManagerHooks
+}
+
+// NewHookedResourceManager returns an AWSResourceManager that calls the
+// supplied hooks around the methods of the supplied AWSResourceManager
+func NewHookedResourceManager(
+	rm acktypes.AWSResourceManager,
+	hooks *acktypes.ResourceManagerHooks,
+) acktypes.AWSResourceManager {
+	return &hookedResourceManager{rm, hooks}
+}
+
+// ReadOne calls the PreReadOne hooks, the ReadOne method of the wrapped
+// resource manager and the PostReadOne hooks
+func (rm *hookedResourceManager) ReadOne(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	for _, hook := range rm.hooks.PreReadOne {
+		if err := hook(ctx, res); err != nil {
+			return nil, err
+		}
+	}
+	latest, err := rm.AWSResourceManager.ReadOne(ctx, res)
+	for _, hook := range rm.hooks.PostReadOne {
+		latest, err = hook(ctx, res, latest, err)
+	}
+	return latest, err
+}
+
+// Create calls the PreCreate hooks, the Create method of the wrapped resource
+// manager and the PostCreate hooks
+func (rm *hookedResourceManager) Create(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+) (acktypes.AWSResource, error) {
+	for _, hook := range rm.hooks.PreCreate {
+		if err := hook(ctx, desired); err != nil {
+			return nil, err
+		}
+	}
+	created, err := rm.AWSResourceManager.Create(ctx, desired)
+	for _, hook := range rm.hooks.PostCreate {
+		created, err = hook(ctx, desired, created, err)
+	}
+	return created, err
+}
+
+// Update calls the PreUpdate hooks, the Update method of the wrapped resource
+// manager and the PostUpdate hooks
+func (rm *hookedResourceManager) Update(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	diff *ackcompare.Reporter,
+) (acktypes.AWSResource, error) {
+	for _, hook := range rm.hooks.PreUpdate {
+		if err := hook(ctx, desired, latest, diff); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := rm.AWSResourceManager.Update(ctx, desired, latest, diff)
+	for _, hook := range rm.hooks.PostUpdate {
+		updated, err = hook(ctx, desired, latest, diff, updated, err)
+	}
+	return updated, err
+}
+
+// Delete calls the PreDelete hooks, the Delete method of the wrapped resource
+// manager and the PostDelete hooks
+func (rm *hookedResourceManager) Delete(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) error {
+	for _, hook := range rm.hooks.PreDelete {
+		if err := hook(ctx, res); err != nil {
+			return err
+		}
+	}
+	err := rm.AWSResourceManager.Delete(ctx, res)
+	for _, hook := range rm.hooks.PostDelete {
+		err = hook(ctx, res, err)
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestHookedResourceManager(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	desired := &mocks.AWSResource{}
+	latest := &mocks.AWSResource{}
+	hooked := &mocks.AWSResource{}
+
+	calls := []string{}
+	hooks := &acktypes.ResourceManagerHooks{
+		PreReadOne: []acktypes.PreReadOneHook{
+			func(ctx context.Context, res acktypes.AWSResource) error {
+				calls = append(calls, "PreReadOne")
+				return nil
+			},
+		},
+		PostReadOne: []acktypes.PostReadOneHook{
+			// Post hooks can replace the result of the call
+			func(
+				ctx context.Context, res acktypes.AWSResource,
+				latest acktypes.AWSResource, err error,
+			) (acktypes.AWSResource, error) {
+				calls = append(calls, "PostReadOne")
+				require.Equal(ackerr.NotFound, err)
+				return hooked, nil
+			},
+		},
+		PreCreate: []acktypes.PreCreateHook{
+			// Pre hooks can prevent the call
+			func(ctx context.Context, desired acktypes.AWSResource) error {
+				calls = append(calls, "PreCreate")
+				return errors.New("not yet")
+			},
+		},
+		PostCreate: []acktypes.PostCreateHook{
+			func(
+				ctx context.Context, desired acktypes.AWSResource,
+				created acktypes.AWSResource, err error,
+			) (acktypes.AWSResource, error) {
+				calls = append(calls, "PostCreate")
+				return created, err
+			},
+		},
+		PreUpdate: []acktypes.PreUpdateHook{
+			func(
+				ctx context.Context, desired acktypes.AWSResource,
+				latest acktypes.AWSResource, diff *ackcompare.Reporter,
+			) error {
+				calls = append(calls, "PreUpdate")
+				return nil
+			},
+		},
+		PostDelete: []acktypes.PostDeleteHook{
+			func(
+				ctx context.Context, res acktypes.AWSResource, err error,
+			) error {
+				calls = append(calls, "PostDelete")
+				return err
+			},
+		},
+	}
+	hooks.Append(&acktypes.ResourceManagerHooks{
+		PostUpdate: []acktypes.PostUpdateHook{
+			func(
+				ctx context.Context, desired acktypes.AWSResource,
+				latest acktypes.AWSResource, diff *ackcompare.Reporter,
+				updated acktypes.AWSResource, err error,
+			) (acktypes.AWSResource, error) {
+				calls = append(calls, "PostUpdate")
+				return updated, err
+			},
+		},
+	})
+
+	rm := &mocks.AWSResourceManager{}
+	rm.On("ReadOne", ctx, desired).Return(nil, ackerr.NotFound)
+	rm.On("Update", ctx, desired, latest, mock.Anything).Return(latest, nil)
+	rm.On("Delete", ctx, desired).Return(nil)
+
+	hrm := ackrt.NewHookedResourceManager(rm, hooks)
+
+	res, err := hrm.ReadOne(ctx, desired)
+	require.Nil(err)
+	require.Equal(hooked, res)
+
+	_, err = hrm.Create(ctx, desired)
+	require.NotNil(err)
+	rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	res, err = hrm.Update(ctx, desired, latest, &ackcompare.Reporter{})
+	require.Nil(err)
+	require.Equal(latest, res)
+
+	require.Nil(hrm.Delete(ctx, desired))
+
+	require.Equal([]string{
+		"PreReadOne", "PostReadOne", "PreCreate", "PreUpdate", "PostUpdate",
+		"PostDelete",
+	}, calls)
+	rm.AssertExpectations(t)
+}
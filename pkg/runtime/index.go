@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// resourceIndex looks up the CRs of a single kind by the values of the field
+// indexes of the controller manager's cache
+type resourceIndex struct {
+	kc      client.Reader
+	scheme  *k8sruntime.Scheme
+	listGVK schema.GroupVersionKind
+}
+
+// newResourceIndex returns a resourceIndex for the kind of the supplied
+// object, which must be registered with the supplied scheme
+func newResourceIndex(
+	kc client.Reader,
+	scheme *k8sruntime.Scheme,
+	obj k8sruntime.Object,
+) (*resourceIndex, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	return &resourceIndex{
+		kc:      kc,
+		scheme:  scheme,
+		listGVK: gvk.GroupVersion().WithKind(gvk.Kind + "List"),
+	}, nil
+}
+
+// lookup returns the namespaced names of the CRs whose field index with the
+// supplied name contains the supplied value
+func (i *resourceIndex) lookup(
+	ctx context.Context,
+	field string,
+	value string,
+) ([]k8stypes.NamespacedName, error) {
+	items, err := i.list(ctx, client.MatchingFields{field: value})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]k8stypes.NamespacedName, 0, len(items))
+	for _, item := range items {
+		mo, err := meta.Accessor(item)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, k8stypes.NamespacedName{
+			Namespace: mo.GetNamespace(),
+			Name:      mo.GetName(),
+		})
+	}
+	return names, nil
+}
+
+// list returns the CRs matching the supplied list options
+func (i *resourceIndex) list(
+	ctx context.Context,
+	opts ...client.ListOption,
+) ([]k8sruntime.Object, error) {
+	list, err := i.scheme.New(i.listGVK)
+	if err != nil {
+		return nil, err
+	}
+	if err = i.kc.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	return meta.ExtractList(list)
+}
@@ -0,0 +1,266 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	flag "github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	kubernetes "k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// flagEnableServicePrefix is the prefix of the flags that enable each of
+	// the service controllers hosted by a multi-service controller binary,
+	// e.g. --enable-s3
+	flagEnableServicePrefix = "enable-"
+	// multiServiceLeaderElectionIDSuffix is the suffix of the leader election
+	// ID of a manager hosting more than one service controller
+	multiServiceLeaderElectionIDSuffix = ".services.k8s.aws"
+)
+
+var (
+	setupLog = ctrlrt.Log.WithName("setup")
+)
+
+// Run is the entrypoint of service controller binaries. It parses the command
+// line flags, loads the configuration, creates a single controller-runtime
+// Manager hosting the supplied service controllers and runs the Manager until
+// the process is signalled to stop.
+//
+// When more than one service controller is supplied, each one can be disabled
+// with an --enable-<service alias>=false flag. The errors returned by Run
+// have already been logged.
+func Run(scs ...*ServiceController) error {
+	var cfg Config
+	cfg.BindFlags()
+	enabled := bindEnableServiceFlags(scs)
+	flag.Parse()
+	// The configuration file and ConfigMap may change the log level, so the
+	// logger is set up after they are read
+	loadErr := cfg.Load()
+	cfg.SetupLogger()
+
+	if loadErr != nil {
+		setupLog.Error(loadErr, "Unable to load controller configuration")
+		return loadErr
+	}
+	if err := cfg.Validate(); err != nil {
+		setupLog.Error(err, "Unable to create controller manager")
+		return err
+	}
+	enabledSCs := []*ServiceController{}
+	for _, sc := range scs {
+		if *enabled[sc.ServiceAlias] {
+			enabledSCs = append(enabledSCs, sc)
+		}
+	}
+	if len(enabledSCs) == 0 {
+		err := errors.New("no service controllers are enabled")
+		setupLog.Error(err, "Unable to create controller manager")
+		return err
+	}
+	gks := []*metav1.GroupKind{}
+	for _, sc := range enabledSCs {
+		gks = append(gks, sc.resourceGroupKinds()...)
+	}
+	if err := cfg.ValidateResources(gks); err != nil {
+		setupLog.Error(err, "Unable to create controller manager")
+		return err
+	}
+
+	mgr, err := NewManager(cfg, enabledSCs...)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller manager")
+		return err
+	}
+	// The components shared by the service controllers, e.g. the event
+	// source and the audit sink, are created once so that the service
+	// controllers don't compete for the same notifications or files
+	shared, err := newManagerComponents(mgr, cfg, ctrlrt.Log)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller manager components")
+		return err
+	}
+	for _, sc := range enabledSCs {
+		sc.shared = shared
+		setupLog.Info(
+			"initializing service controller",
+			"aws.service", sc.ServiceAlias,
+		)
+		if sc.log == nil {
+			sc.WithLogger(ctrlrt.Log)
+		}
+		if err = sc.BindControllerManager(mgr, cfg); err != nil {
+			setupLog.Error(
+				err, "unable bind to controller manager to service controller",
+				"aws.service", sc.ServiceAlias,
+			)
+			return err
+		}
+	}
+
+	stopChan := ctrlrt.SetupSignalHandler()
+
+	setupLog.Info(
+		"starting manager",
+		"aws.services", serviceAliases(enabledSCs),
+	)
+	if err = mgr.Start(stopChan); err != nil {
+		setupLog.Error(err, "unable to start controller manager")
+		return err
+	}
+	return nil
+}
+
+// NewManager returns a controller-runtime Manager, configured with the
+// supplied Config, that can host the supplied service controllers. The
+// Kubernetes API types of the service controllers are registered with the
+// Manager's scheme.
+func NewManager(
+	cfg Config,
+	scs ...*ServiceController,
+) (ctrlrt.Manager, error) {
+	scheme := k8sruntime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	for _, sc := range scs {
+		if sc.addToScheme == nil {
+			continue
+		}
+		if err := sc.addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return ctrlrt.NewManager(ctrlrt.GetConfigOrDie(), ctrlrt.Options{
+		Scheme:             scheme,
+		Port:               cfg.BindPort,
+		MetricsBindAddress: cfg.MetricsAddr,
+		LeaderElection:     cfg.EnableLeaderElection,
+		LeaderElectionID:   leaderElectionID(scs),
+	})
+}
+
+// managerComponents are the components of a controller-runtime Manager that
+// are shared by all the service controllers hosted by the Manager
+type managerComponents struct {
+	// audit records the mutating calls to the backend AWS service APIs. It's
+	// nil if auditing is disabled.
+	audit AuditSink
+	// live is the configuration reloaded from the config ConfigMap
+	live *liveConfig
+	// policy holds the rules read from the policy ConfigMap. It's nil if no
+	// policy ConfigMap is configured.
+	policy *RulesPolicy
+	// events receives AWS resource change notifications. It's nil if no
+	// event queue is configured.
+	events *eventSource
+}
+
+// newManagerComponents creates the components shared by the service
+// controllers hosted by the supplied Manager and adds the ones that need to
+// run to the Manager
+func newManagerComponents(
+	mgr ctrlrt.Manager,
+	cfg Config,
+	log logr.Logger,
+) (*managerComponents, error) {
+	audit, err := newAuditSink(cfg.AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	var clientset kubernetes.Interface
+	if cfg.ConfigMap != "" || cfg.PolicyConfigMap != "" {
+		if clientset, err = kubernetes.NewForConfig(mgr.GetConfig()); err != nil {
+			return nil, err
+		}
+	}
+	c := &managerComponents{
+		audit: audit,
+		live:  newLiveConfig(cfg, clientset, log),
+	}
+	if err = mgr.Add(c.live); err != nil {
+		return nil, err
+	}
+	if cfg.PolicyConfigMap != "" {
+		c.policy = NewRulesPolicy()
+		ns, name := splitConfigMapName(cfg.PolicyConfigMap)
+		watcher := newPolicyConfigMapWatcher(clientset, ns, name, c.policy, log)
+		if err = mgr.Add(watcher); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.EventQueueURL != "" {
+		if c.events, err = newEventSource(cfg, log); err != nil {
+			return nil, err
+		}
+		if err = mgr.Add(c.events); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// bindEnableServiceFlags binds an --enable-<service alias> flag for each of
+// the supplied service controllers if there is more than one, and returns
+// the values of the flags keyed by service alias. Service controllers are
+// enabled by default.
+func bindEnableServiceFlags(scs []*ServiceController) map[string]*bool {
+	enabled := make(map[string]*bool, len(scs))
+	for _, sc := range scs {
+		enabled[sc.ServiceAlias] = new(bool)
+		*enabled[sc.ServiceAlias] = true
+		if len(scs) == 1 {
+			continue
+		}
+		flag.BoolVar(
+			enabled[sc.ServiceAlias], flagEnableServicePrefix+sc.ServiceAlias,
+			true,
+			"Enable the service controller for the "+sc.ServiceAlias+" AWS service API",
+		)
+	}
+	return enabled
+}
+
+// leaderElectionID returns the ID of the leader election lock of a manager
+// hosting the supplied service controllers. A manager hosting a single
+// service controller uses the service's API group, e.g. "s3.services.k8s.aws",
+// so that it can replace a single-service controller binary. Otherwise the
+// ID contains the sorted aliases of the services, e.g.
+// "ecr-s3.services.k8s.aws".
+func leaderElectionID(scs []*ServiceController) string {
+	if len(scs) == 1 {
+		return scs[0].ServiceAPIGroup
+	}
+	return strings.Join(serviceAliases(scs), "-") + multiServiceLeaderElectionIDSuffix
+}
+
+// serviceAliases returns the sorted aliases of the supplied service
+// controllers
+func serviceAliases(scs []*ServiceController) []string {
+	aliases := make([]string, 0, len(scs))
+	for _, sc := range scs {
+		aliases = append(aliases, sc.ServiceAlias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
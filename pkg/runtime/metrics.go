@@ -0,0 +1,69 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// orphanedResources is the number of AWS resources of each kind that the
+	// ownership tags claim are managed by the service controller but whose
+	// CR doesn't exist
+	orphanedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ack_orphaned_resources",
+			Help: "Number of AWS resources managed by the service controller whose CR doesn't exist",
+		},
+		[]string{"group", "kind"},
+	)
+	// orphanedResourcesDeleted counts the orphaned AWS resources of each kind
+	// deleted by the service controller
+	orphanedResourcesDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_orphaned_resources_deleted_total",
+			Help: "Total number of orphaned AWS resources deleted by the service controller",
+		},
+		[]string{"group", "kind"},
+	)
+	// driftDetected counts the resources of each kind found to have drifted
+	// from their desired state while their drift policy was report
+	driftDetected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_drift_detected_total",
+			Help: "Total number of times resources were found to have drifted from their desired state with the report drift policy",
+		},
+		[]string{"group", "kind"},
+	)
+	// eventReconciles counts the CRs of each kind enqueued because a
+	// notification about their backend AWS service API resource was received
+	eventReconciles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_event_reconciles_total",
+			Help: "Total number of reconciliations triggered by AWS resource change notifications",
+		},
+		[]string{"group", "kind"},
+	)
+)
+
+func init() {
+	// The metrics are served by the controller manager's metrics endpoint
+	ctrlmetrics.Registry.MustRegister(
+		orphanedResources,
+		orphanedResourcesDeleted,
+		driftDetected,
+		eventReconciles,
+	)
+}
@@ -0,0 +1,260 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+// orphanScanner periodically lists the resources of a single kind in the
+// backend AWS service API and looks for orphaned resources: resources whose
+// ownership tags claim they're managed by the service controller but whose CR
+// doesn't exist, e.g. because the CR's finalizer was removed by hand or the
+// cluster was rebuilt. Orphaned resources are reported with metrics and with
+// events on the namespace of their missing CR, and are deleted once they've
+// been orphaned for longer than the deletion grace period, if there is one.
+//
+// Only the resources in the service controller's AWS account and region that
+// have the tags supplied to the --resource-tags flag and the cluster ID tag
+// with the value of the --cluster-id flag are scanned, and only if the
+// resource manager implements AWSResourceLister. Config.Validate() ensures
+// the cluster ID is set, so that resources managed by other clusters in the
+// same account and region are never reported or deleted. Resources whose missing
+// CR would be in an ignored namespace are left alone.
+//
+// orphanScanner implements the upstream controller-runtime
+// `manager.Runnable` interface.
+type orphanScanner struct {
+	r   *reconciler
+	log logr.Logger
+	// period is how often the resources are scanned
+	period time.Duration
+	// gracePeriod is how long a resource must stay orphaned before it's
+	// deleted. Zero means orphaned resources are never deleted.
+	gracePeriod time.Duration
+	// requiredTags are the tags a resource must have to be scanned,
+	// including the tag identifying the service controller's cluster
+	requiredTags map[string]string
+	// orphans maps the keys of the orphaned resources found by the last
+	// scan to when they were first found orphaned
+	orphans map[string]time.Time
+}
+
+// newOrphanScanner returns an orphanScanner for the kind of resource reconciled
+// by the supplied reconciler
+func newOrphanScanner(r *reconciler) *orphanScanner {
+	// Config.Validate() ensures the flag values are well-formed
+	requiredTags, _ := parseTags(r.cfg.ResourceTags)
+	requiredTags[ackv1alpha1.TagKeyClusterID] = r.cfg.ClusterID
+	return &orphanScanner{
+		r:            r,
+		log:          r.log.WithName("orphans").WithValues("kind", r.rd.GroupKind().String()),
+		period:       r.cfg.OrphanScanPeriod.Duration,
+		gracePeriod:  r.cfg.OrphanGracePeriod.Duration,
+		requiredTags: requiredTags,
+		orphans:      map[string]time.Time{},
+	}
+}
+
+// NeedLeaderElection implements the upstream controller-runtime
+// `manager.LeaderElectionRunnable` interface. Only the leader scans for
+// orphaned resources, so that they're deleted once.
+func (s *orphanScanner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the upstream controller-runtime `manager.Runnable`
+// interface, scanning for orphaned resources every period until the supplied
+// channel is closed
+func (s *orphanScanner) Start(stopCh <-chan struct{}) error {
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case <-ticker.C:
+			if err := s.scan(context.Background()); err != nil {
+				s.log.Error(err, "unable to scan for orphaned resources")
+			}
+		}
+	}
+}
+
+// scan lists the resources in the backend AWS service API, reports the ones
+// that are orphaned and deletes the ones that have been orphaned for longer
+// than the grace period
+func (s *orphanScanner) scan(ctx context.Context) error {
+	if !s.r.cache.Namespaces.HasSynced() {
+		return nil
+	}
+	// The reconciler's configuration is refreshed by every reconciliation,
+	// so we use our own copy
+	cfg := s.r.live.forGroupKind(s.r.rd.GroupKind())
+	acctID := ackv1alpha1.AWSAccountID(cfg.AccountID)
+	region := ackv1alpha1.AWSRegion(cfg.Region)
+	rm, err := s.r.defaultResourceManager(cfg)
+	if err != nil {
+		return err
+	}
+	lister, ok := rm.(acktypes.AWSResourceLister)
+	if !ok {
+		s.log.V(1).Info("resource manager can't list resources, skipping scan")
+		return nil
+	}
+	resources, err := lister.ReadMany(ctx)
+	if err != nil {
+		return ackerr.Wrap(err, s.r.rd.GroupKind(), "ReadMany")
+	}
+
+	now := time.Now()
+	orphans := map[string]time.Time{}
+	for _, res := range resources {
+		owner, ok := TaggedOwner(res)
+		if !ok || !s.hasRequiredTags(res) ||
+			s.r.cache.Namespaces.IsIgnored(owner.Namespace) {
+			continue
+		}
+		err = s.r.kc.Get(ctx, owner, s.r.rd.EmptyRuntimeObject())
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		// Identify the orphaned resource by the CR it belongs to in the
+		// events and audit records about it
+		res.MetaObject().SetNamespace(owner.Namespace)
+		res.MetaObject().SetName(owner.Name)
+		key := orphanKey(res, owner)
+		firstSeen, found := s.orphans[key]
+		if !found {
+			firstSeen = now
+			s.log.Info(
+				"found orphaned resource",
+				"arn", res.Identifiers().ARN(),
+				"namespace", owner.Namespace,
+				"name", owner.Name,
+			)
+			s.recordEvent(
+				owner, corev1.EventTypeWarning, "OrphanedResource",
+				fmt.Sprintf(
+					"%s %s is managed by the service controller but its CR %s doesn't exist",
+					s.r.rd.GroupKind().Kind, key, owner,
+				),
+			)
+		}
+		orphans[key] = firstSeen
+		if s.gracePeriod == 0 || now.Sub(firstSeen) < s.gracePeriod {
+			continue
+		}
+		if err = s.delete(ctx, rm, res, acctID, region); err != nil {
+			s.log.Error(
+				err, "unable to delete orphaned resource",
+				"arn", res.Identifiers().ARN(),
+			)
+			continue
+		}
+		delete(orphans, key)
+		s.recordEvent(
+			owner, corev1.EventTypeNormal, "DeletedOrphanedResource",
+			fmt.Sprintf(
+				"deleted %s %s, which was orphaned for longer than %s",
+				s.r.rd.GroupKind().Kind, key, s.gracePeriod,
+			),
+		)
+	}
+	s.orphans = orphans
+	gk := s.r.rd.GroupKind()
+	orphanedResources.WithLabelValues(gk.Group, gk.Kind).Set(float64(len(orphans)))
+	return nil
+}
+
+// delete deletes the supplied orphaned resource, unless a policy denies it
+func (s *orphanScanner) delete(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	res acktypes.AWSResource,
+	acctID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+) error {
+	err := s.r.evaluatePolicies(
+		ctx, acktypes.PolicyOperationDelete, res, res, nil,
+	)
+	if err != nil {
+		return err
+	}
+	if s.r.hooks != nil {
+		rm = NewHookedResourceManager(rm, s.r.hooks)
+	}
+	ctx = s.r.withAuditInfo(ctx, res, acctID, region)
+	if err = rm.Delete(withAuditAction(ctx, "DeleteOrphan"), res); err != nil {
+		return ackerr.Wrap(err, s.r.rd.GroupKind(), "Delete")
+	}
+	gk := s.r.rd.GroupKind()
+	orphanedResourcesDeleted.WithLabelValues(gk.Group, gk.Kind).Inc()
+	s.log.Info("deleted orphaned resource", "arn", res.Identifiers().ARN())
+	return nil
+}
+
+// hasRequiredTags returns true if the supplied resource has all the tags
+// supplied to the --resource-tags flag and the tag identifying the service
+// controller's cluster
+func (s *orphanScanner) hasRequiredTags(res acktypes.AWSResource) bool {
+	tags := res.(acktypes.TaggableAWSResource).GetTags()
+	for k, v := range s.requiredTags {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// recordEvent emits a Kubernetes event about an orphaned resource on the
+// namespace of its missing CR
+func (s *orphanScanner) recordEvent(
+	owner k8stypes.NamespacedName,
+	eventType string,
+	reason string,
+	message string,
+) {
+	if s.r.recorder == nil {
+		return
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: owner.Namespace},
+	}
+	s.r.recorder.Event(ns, eventType, reason, message)
+}
+
+// orphanKey returns the string identifying the supplied orphaned resource: its
+// ARN or, if it has none, the namespace and name of its missing CR
+func orphanKey(res acktypes.AWSResource, owner k8stypes.NamespacedName) string {
+	if arn := res.Identifiers().ARN(); arn != nil {
+		return string(*arn)
+	}
+	return owner.String()
+}
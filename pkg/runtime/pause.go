@@ -0,0 +1,118 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// pausedRequeueDelay is how long to wait before checking again whether
+	// reconciliation of a resource is still paused. Removing the annotation
+	// from a CR triggers a reconciliation, but removing it from a namespace
+	// doesn't.
+	pausedRequeueDelay = time.Minute
+	// eventReasonReconcilePaused is the reason of the event emitted when
+	// reconciliation of a resource is paused
+	eventReasonReconcilePaused = "ReconcilePaused"
+	// eventReasonReconcileResumed is the reason of the event emitted when
+	// reconciliation of a resource resumes
+	eventReasonReconcileResumed = "ReconcileResumed"
+)
+
+// isReconcilePaused returns true if the supplied resource, or its namespace,
+// has the services.k8s.aws/reconcile-paused annotation set to "true"
+func (r *reconciler) isReconcilePaused(res acktypes.AWSResource) bool {
+	mo := res.MetaObject()
+	if val, ok := mo.GetAnnotations()[ackv1alpha1.AnnotationReconcilePaused]; ok {
+		paused, _ := strconv.ParseBool(val)
+		return paused
+	}
+	return r.cache.Namespaces.IsReconcilePaused(mo.GetNamespace())
+}
+
+// pause handles the reconciliation of a resource while reconciliation is
+// paused. No calls are made to the backend AWS service API, except to read the
+// state of the resource if the service controller is configured to do so.
+// The resource's ACK.ReconcilePaused condition is set to True.
+func (r *reconciler) pause(
+	ctx context.Context,
+	rm acktypes.AWSResourceManager,
+	current acktypes.AWSResource,
+) error {
+	latest := r.rd.ResourceFromRuntimeObject(
+		current.RuntimeObject().DeepCopyObject(),
+	)
+	if r.cfg.ReadWhilePaused && r.rd.IsManaged(current) {
+		observed, err := rm.ReadOne(ctx, current)
+		if err != nil && err != ackerr.NotFound {
+			return ackerr.Wrap(err, r.rd.GroupKind(), "ReadOne")
+		}
+		if err == nil {
+			latest = observed
+		}
+	}
+	wasPaused := isReconcilePausedCondition(current)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeReconcilePaused,
+		corev1.ConditionTrue,
+		"reconciliation is paused by the "+ackv1alpha1.AnnotationReconcilePaused+" annotation",
+	)
+	if !r.rd.Equal(current, latest) {
+		if err := r.patchResourceStatus(ctx, current, latest); err != nil {
+			return err
+		}
+	}
+	if !wasPaused {
+		r.log.V(0).Info("reconciliation is paused")
+		r.recordEvent(
+			latest, corev1.EventTypeNormal, eventReasonReconcilePaused,
+			"Reconciliation is paused; the service controller won't modify the AWS resource",
+		)
+	}
+	return requeue.NeededAfter(ackerr.ReconcilePaused, pausedRequeueDelay)
+}
+
+// resume sets the ACK.ReconcilePaused condition of the supplied resource to
+// False if reconciliation of the resource was paused
+func (r *reconciler) resume(res acktypes.AWSResource) {
+	if !isReconcilePausedCondition(res) {
+		return
+	}
+	SetCondition(
+		res, ackv1alpha1.ConditionTypeReconcilePaused,
+		corev1.ConditionFalse, "",
+	)
+	r.log.V(0).Info("reconciliation has resumed")
+	r.recordEvent(
+		res, corev1.EventTypeNormal, eventReasonReconcileResumed,
+		"Reconciliation has resumed",
+	)
+}
+
+// isReconcilePausedCondition returns true if the supplied resource has an
+// ACK.ReconcilePaused condition with a True status
+func isReconcilePausedCondition(res acktypes.AWSResource) bool {
+	paused := GetCondition(res, ackv1alpha1.ConditionTypeReconcilePaused)
+	return paused != nil && paused.Status == corev1.ConditionTrue
+}
@@ -0,0 +1,442 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	ackrtcache "github.com/aws/aws-controllers-k8s/pkg/runtime/cache"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// PolicyConfigMapKey is the key of the YAML policy rules in the ConfigMap
+	// containing the rules evaluated by the built-in RulesPolicy
+	PolicyConfigMapKey = "rules.yaml"
+	// eventReasonPolicyDenied is the reason of the event emitted when a
+	// policy denies a mutating call to the backend AWS service API
+	eventReasonPolicyDenied = "PolicyDenied"
+)
+
+// PolicyRules is the YAML document, read from a ConfigMap, containing the
+// rules evaluated by a RulesPolicy
+type PolicyRules struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule denies the operations that match all of its criteria. Criteria
+// that are empty match every operation.
+//
+// For example, this rule denies deleting any resource in the prod namespace:
+//
+//   - name: no-deletes-in-prod
+//     operations: [Delete]
+//     namespaces: [prod]
+//     message: resources in prod must be deleted manually
+type PolicyRule struct {
+	// Name identifies the rule in denial messages
+	Name string `json:"name"`
+	// Message explains to the Kubernetes user why the operation is denied
+	Message string `json:"message,omitempty"`
+	// GroupKinds are the kinds of resources the rule applies to, e.g.
+	// "Repository.ecr.services.k8s.aws"
+	GroupKinds []string `json:"groupKinds,omitempty"`
+	// Operations are the operations the rule applies to
+	Operations []acktypes.PolicyOperation `json:"operations,omitempty"`
+	// Namespaces are the namespaces of the CRs the rule applies to
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Fields match the values of fields of the desired CR
+	Fields []PolicyFieldMatch `json:"fields,omitempty"`
+	// Changed are the dot-separated paths of the JSON field names of fields
+	// of the CR, like the paths of Fields, e.g. "spec.tags". The rule matches
+	// an Update operation if any of these fields, or the fields nested in
+	// them, changed. Every field counts as changed for a Create operation and
+	// none does for a Delete operation.
+	Changed []string `json:"changed,omitempty"`
+}
+
+// PolicyFieldMatch matches the value of a field of the desired CR, identified
+// by the dot-separated path of its JSON field names, e.g.
+// "spec.imageScanningConfiguration.scanOnPush". Values are compared in their
+// string representation. A missing field has no value, so it matches NotEquals
+// but not Equals.
+type PolicyFieldMatch struct {
+	Path      string  `json:"path"`
+	Equals    *string `json:"equals,omitempty"`
+	NotEquals *string `json:"notEquals,omitempty"`
+	Exists    *bool   `json:"exists,omitempty"`
+}
+
+// RulesPolicy is the built-in `types.Policy` that denies operations matching
+// any of a set of rules
+type RulesPolicy struct {
+	sync.RWMutex
+	rules []PolicyRule
+}
+
+// NewRulesPolicy returns a RulesPolicy with no rules, which allows every
+// operation
+func NewRulesPolicy() *RulesPolicy {
+	return &RulesPolicy{}
+}
+
+// SetRules replaces the policy's rules with the rules in the supplied YAML
+// document. The rules are unchanged if the document isn't valid.
+func (p *RulesPolicy) SetRules(data []byte) error {
+	var rules PolicyRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return err
+	}
+	for i, rule := range rules.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("policy rule %d has no name", i)
+		}
+		for _, field := range rule.Fields {
+			if field.Path == "" {
+				return fmt.Errorf("policy rule %s has a field with no path", rule.Name)
+			}
+		}
+		for _, path := range rule.Changed {
+			if strings.Contains("."+path+".", "..") || unicode.IsUpper(rune(path[0])) {
+				return fmt.Errorf(
+					"policy rule %s has changed path %q, which is not a path of JSON field names, e.g. spec.tags",
+					rule.Name, path,
+				)
+			}
+		}
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.rules = rules.Rules
+	return nil
+}
+
+// Evaluate implements `types.Policy` and denies the supplied request if it
+// matches any of the policy's rules
+func (p *RulesPolicy) Evaluate(
+	ctx context.Context,
+	req *acktypes.PolicyRequest,
+) error {
+	p.RLock()
+	defer p.RUnlock()
+	if len(p.rules) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(req.Desired.RuntimeObject())
+	if err != nil {
+		return err
+	}
+	var obj map[string]interface{}
+	if err = json.Unmarshal(raw, &obj); err != nil {
+		return err
+	}
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if !rule.matches(req, obj) {
+			continue
+		}
+		msg := rule.Message
+		if msg == "" {
+			msg = fmt.Sprintf("%s is not allowed", strings.ToLower(string(req.Operation)))
+		}
+		return fmt.Errorf("%w: rule %s: %s", ackerr.PolicyDenied, rule.Name, msg)
+	}
+	return nil
+}
+
+// matches returns true if the supplied request, whose desired CR is also
+// supplied as a generic JSON object, matches all the criteria of the rule
+func (rule *PolicyRule) matches(
+	req *acktypes.PolicyRequest,
+	obj map[string]interface{},
+) bool {
+	if len(rule.GroupKinds) > 0 &&
+		!containsString(rule.GroupKinds, req.GroupKind.String()) {
+		return false
+	}
+	if len(rule.Operations) > 0 {
+		found := false
+		for _, op := range rule.Operations {
+			if op == req.Operation {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	ns := req.Desired.MetaObject().GetNamespace()
+	if len(rule.Namespaces) > 0 && !containsString(rule.Namespaces, ns) {
+		return false
+	}
+	for _, field := range rule.Fields {
+		if !field.matches(obj) {
+			return false
+		}
+	}
+	if len(rule.Changed) > 0 {
+		switch req.Operation {
+		case acktypes.PolicyOperationCreate:
+		case acktypes.PolicyOperationUpdate:
+			if req.Diff == nil {
+				return false
+			}
+			changed := false
+			for _, path := range rule.Changed {
+				if req.Diff.DifferentAt(diffPath(req.Desired.RuntimeObject(), path)) {
+					changed = true
+					break
+				}
+			}
+			if !changed {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the value of the field in the supplied generic JSON
+// object matches
+func (field *PolicyFieldMatch) matches(obj map[string]interface{}) bool {
+	val, found := fieldValue(obj, field.Path)
+	if field.Exists != nil && *field.Exists != found {
+		return false
+	}
+	if field.Equals != nil && (!found || val != *field.Equals) {
+		return false
+	}
+	if field.NotEquals != nil && found && val == *field.NotEquals {
+		return false
+	}
+	return true
+}
+
+// fieldValue returns the string representation of the value at the supplied
+// dot-separated path in the supplied generic JSON object, and whether there is
+// a value at that path
+func fieldValue(obj map[string]interface{}, path string) (string, bool) {
+	var cur interface{} = obj
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if cur, ok = m[part]; !ok || cur == nil {
+			return "", false
+		}
+	}
+	switch cur.(type) {
+	case map[string]interface{}, []interface{}:
+		raw, _ := json.Marshal(cur)
+		return string(raw), true
+	}
+	return fmt.Sprintf("%v", cur), true
+}
+
+// diffPath translates the supplied dot-separated path of JSON field names of
+// a field of the supplied CR, e.g. "spec.kmsMasterKeyID", into the path of
+// the Go field names used by the diffs of resources, e.g.
+// "Spec.KMSMasterKeyID". The JSON field names are looked up in the `json`
+// tags of the CR's Go type. Names that can't be looked up, e.g. in maps, are
+// capitalized.
+func diffPath(obj interface{}, path string) string {
+	t := reflect.TypeOf(obj)
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice ||
+			t.Kind() == reflect.Map) {
+			t = t.Elem()
+		}
+		var field *reflect.StructField
+		if t != nil && t.Kind() == reflect.Struct {
+			field = jsonField(t, part)
+		}
+		if field == nil {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+			t = nil
+			continue
+		}
+		parts[i] = field.Name
+		t = field.Type
+	}
+	return strings.Join(parts, ".")
+}
+
+// jsonField returns the field of the supplied struct type, or of the structs
+// embedded in it, whose JSON field name is the supplied name, or nil if there
+// is none
+func jsonField(t reflect.Type, name string) *reflect.StructField {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Anonymous && tagName == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if f := jsonField(ft, name); f != nil {
+					return f
+				}
+			}
+			continue
+		}
+		if tagName == name {
+			return &field
+		}
+	}
+	return nil
+}
+
+// containsString returns true if the supplied slice contains the supplied
+// string
+func containsString(values []string, val string) bool {
+	for _, v := range values {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// policyConfigMapWatcher keeps the rules of a RulesPolicy in sync with the
+// rules in a ConfigMap. It implements the upstream controller-runtime
+// `manager.Runnable` interface.
+type policyConfigMapWatcher struct {
+	log    logr.Logger
+	policy *RulesPolicy
+	cache  *ackrtcache.ConfigCache
+}
+
+// newPolicyConfigMapWatcher returns a policyConfigMapWatcher updating the
+// supplied RulesPolicy from the ConfigMap with the supplied namespace and
+// name
+func newPolicyConfigMapWatcher(
+	clientset kubernetes.Interface,
+	namespace string,
+	name string,
+	policy *RulesPolicy,
+	log logr.Logger,
+) *policyConfigMapWatcher {
+	w := &policyConfigMapWatcher{
+		log:    log.WithName("policy"),
+		policy: policy,
+	}
+	w.cache = ackrtcache.NewConfigCache(
+		clientset, namespace, name, log, w.onChange,
+	)
+	return w
+}
+
+// onChange updates the policy's rules from the supplied ConfigMap data
+func (w *policyConfigMapWatcher) onChange(data map[string]string) {
+	if err := w.policy.SetRules([]byte(data[PolicyConfigMapKey])); err != nil {
+		w.log.Error(err, "ignoring invalid policy rules")
+		return
+	}
+	w.log.Info("loaded policy rules")
+}
+
+// NeedLeaderElection implements the upstream controller-runtime
+// `manager.LeaderElectionRunnable` interface. Every replica of the service
+// controller loads the policy rules, not just the leader.
+func (w *policyConfigMapWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the upstream controller-runtime `manager.Runnable`
+// interface and watches the ConfigMap until the supplied channel is closed
+func (w *policyConfigMapWatcher) Start(stopCh <-chan struct{}) error {
+	w.cache.Run(stopCh)
+	<-stopCh
+	return nil
+}
+
+// evaluatePolicies evaluates the reconciler's policies for the supplied
+// mutating call to the backend AWS service API. It returns an error wrapping
+// ackerrors.PolicyDenied if any policy denies the call.
+func (r *reconciler) evaluatePolicies(
+	ctx context.Context,
+	op acktypes.PolicyOperation,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	diff *ackcompare.Reporter,
+) error {
+	req := &acktypes.PolicyRequest{
+		GroupKind: r.rd.GroupKind(),
+		Operation: op,
+		Desired:   desired,
+		Latest:    latest,
+		Diff:      diff,
+	}
+	for _, policy := range r.policies {
+		if err := policy.Evaluate(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlePolicyError handles an error returned by evaluatePolicies. A policy
+// denial is recorded on the supplied resource as a True ACK.Terminal
+// condition and the resource isn't requeued: it's reconciled again when the
+// CR changes or after the resync period. Other errors are returned as is.
+func (r *reconciler) handlePolicyError(
+	ctx context.Context,
+	current acktypes.AWSResource,
+	denial error,
+) error {
+	if !errors.Is(denial, ackerr.PolicyDenied) {
+		return denial
+	}
+	latest := r.rd.ResourceFromRuntimeObject(
+		current.RuntimeObject().DeepCopyObject(),
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeTerminal,
+		corev1.ConditionTrue, denial.Error(),
+	)
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeResourceSynced,
+		corev1.ConditionFalse, denial.Error(),
+	)
+	r.log.V(0).Info("operation denied by policy", "reason", denial.Error())
+	if r.rd.Equal(current, latest) {
+		return nil
+	}
+	r.recordEvent(
+		latest, corev1.EventTypeWarning, eventReasonPolicyDenied,
+		denial.Error(),
+	)
+	return r.patchResourceStatus(ctx, current, latest)
+}
@@ -0,0 +1,180 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+	snsv1alpha1 "github.com/aws/aws-controllers-k8s/services/sns/apis/v1alpha1"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestRulesPolicy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	policy := ackrt.NewRulesPolicy()
+	require.Nil(policy.SetRules([]byte(`
+rules:
+- name: no-deletes-in-prod
+  operations: [Delete]
+  namespaces: [prod]
+- name: scan-on-push
+  groupKinds: [Repository.ecr.services.k8s.aws]
+  operations: [Create, Update]
+  fields:
+  - path: spec.imageScanningConfiguration.scanOnPush
+    notEquals: "true"
+  message: repositories must scan images on push
+- name: immutable-name
+  operations: [Update]
+  changed: [spec.name]
+`)))
+
+	ecrGK := &metav1.GroupKind{Group: "ecr.services.k8s.aws", Kind: "Repository"}
+	newResource := func(ns string, spec map[string]interface{}) acktypes.AWSResource {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": spec,
+		}}
+		obj.SetNamespace(ns)
+		res := &mocks.AWSResource{}
+		res.On("RuntimeObject").Return(obj)
+		res.On("MetaObject").Return(obj)
+		return res
+	}
+	scanning := map[string]interface{}{
+		"imageScanningConfiguration": map[string]interface{}{
+			"scanOnPush": true,
+		},
+	}
+
+	// Deletes are only denied in prod
+	err := policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationDelete,
+		Desired:   newResource("prod", scanning),
+	})
+	require.True(errors.Is(err, ackerr.PolicyDenied))
+	require.Contains(err.Error(), "no-deletes-in-prod")
+	require.Nil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationDelete,
+		Desired:   newResource("dev", scanning),
+	}))
+
+	// A missing field doesn't equal "true"
+	err = policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationCreate,
+		Desired:   newResource("dev", map[string]interface{}{}),
+	})
+	require.True(errors.Is(err, ackerr.PolicyDenied))
+	require.Contains(err.Error(), "repositories must scan images on push")
+	require.Nil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationCreate,
+		Desired:   newResource("dev", scanning),
+	}))
+	require.Nil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: &metav1.GroupKind{Group: "s3.services.k8s.aws", Kind: "Bucket"},
+		Operation: acktypes.PolicyOperationCreate,
+		Desired:   newResource("dev", map[string]interface{}{}),
+	}))
+
+	// Updates are only denied if the diff contains the changed paths
+	diff := &ackcompare.Reporter{Differences: []ackcompare.DiffItem{
+		{Path: "Spec.Tags", ValueA: "a", ValueB: "b"},
+	}}
+	require.Nil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationUpdate,
+		Desired:   newResource("dev", scanning),
+		Diff:      diff,
+	}))
+	diff.Differences = append(diff.Differences, ackcompare.DiffItem{
+		Path: "Spec.Name", ValueA: "a", ValueB: "b",
+	})
+	err = policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationUpdate,
+		Desired:   newResource("dev", scanning),
+		Diff:      diff,
+	})
+	require.True(errors.Is(err, ackerr.PolicyDenied))
+	require.Contains(err.Error(), "immutable-name")
+
+	// Invalid rules are rejected and the previous rules are kept
+	require.NotNil(policy.SetRules([]byte(`rules: [{operations: [Delete]}]`)))
+	require.NotNil(policy.SetRules([]byte(`rules: [{name: go-path, changed: [Spec.Name]}]`)))
+	require.NotNil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationDelete,
+		Desired:   newResource("prod", scanning),
+	}))
+
+	// No rules allow everything
+	require.Nil(policy.SetRules(nil))
+	require.Nil(policy.Evaluate(ctx, &acktypes.PolicyRequest{
+		GroupKind: ecrGK,
+		Operation: acktypes.PolicyOperationDelete,
+		Desired:   newResource("prod", scanning),
+	}))
+}
+
+func TestRulesPolicyChangedPaths(t *testing.T) {
+	require := require.New(t)
+	ctx := context.TODO()
+
+	policy := ackrt.NewRulesPolicy()
+	require.Nil(policy.SetRules([]byte(`
+rules:
+- name: fixed-kms-key
+  operations: [Update]
+  changed: [spec.kmsMasterKeyID]
+`)))
+
+	// The JSON field names of the paths are translated into the Go field
+	// names of the diff
+	topic := &snsv1alpha1.Topic{}
+	topic.SetNamespace("dev")
+	res := &mocks.AWSResource{}
+	res.On("RuntimeObject").Return(topic)
+	res.On("MetaObject").Return(topic)
+	req := &acktypes.PolicyRequest{
+		GroupKind: &metav1.GroupKind{Group: "sns.services.k8s.aws", Kind: "Topic"},
+		Operation: acktypes.PolicyOperationUpdate,
+		Desired:   res,
+		Diff: &ackcompare.Reporter{Differences: []ackcompare.DiffItem{
+			{Path: "Spec.DisplayName", ValueA: "a", ValueB: "b"},
+		}},
+	}
+	require.Nil(policy.Evaluate(ctx, req))
+	req.Diff.Differences = append(req.Diff.Differences, ackcompare.DiffItem{
+		Path: "Spec.KMSMasterKeyID", ValueA: "a", ValueB: "b",
+	})
+	err := policy.Evaluate(ctx, req)
+	require.True(errors.Is(err, ackerr.PolicyDenied))
+	require.Contains(err.Error(), "fixed-kms-key")
+}
@@ -15,15 +15,26 @@ package runtime
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	kubernetes "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
@@ -32,6 +43,17 @@ import (
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 )
 
+const (
+	// minFailureRequeueDelay and maxFailureRequeueDelay bound the
+	// exponential backoff applied to CRs whose reconciliation fails. These
+	// are the controller-runtime defaults.
+	minFailureRequeueDelay = 5 * time.Millisecond
+	maxFailureRequeueDelay = 1000 * time.Second
+	// namespaceCacheSyncDelay is how long to wait before reconciling a CR
+	// again when the namespace cache hasn't synced yet
+	namespaceCacheSyncDelay = time.Second
+)
+
 // reconciler is responsible for reconciling the state of a SINGLE KIND of
 // Kubernetes custom resources (CRs) that represent AWS service API resources.
 // It implements the upstream controller-runtime `Reconciler` interface.
@@ -40,12 +62,45 @@ import (
 // controller-runtime.Controller objects (each containing a single reconciler
 // object)s and sharing watch and informer queues across those controllers.
 type reconciler struct {
-	kc    client.Client
-	rmf   acktypes.AWSResourceManagerFactory
-	rd    acktypes.AWSResourceDescriptor
-	log   logr.Logger
+	kc  client.Client
+	rmf acktypes.AWSResourceManagerFactory
+	rd  acktypes.AWSResourceDescriptor
+	log logr.Logger
+	// cfg is the configuration for the kind of resource reconciled by the
+	// reconciler. It's refreshed from live at the start of each
+	// reconciliation.
 	cfg   Config
+	live  *liveConfig
 	cache ackrtcache.Caches
+	// limiter limits the rate at which CRs are reconciled
+	limiter *bucketRateLimiter
+	// attempts counts the consecutive requeues of each CR with a backoff
+	attempts *requeue.Attempts
+	// sessions contains the AWS sessions used by the resource managers,
+	// keyed by region, endpoint and credentials
+	sessions     map[sessionKey]*session.Session
+	sessionsLock sync.Mutex
+	// audit records the AWS service API calls made to create, update and
+	// delete resources. It's nil if these calls aren't audited.
+	audit AuditSink
+	// recorder emits Kubernetes events about the reconciled CRs
+	recorder record.EventRecorder
+	// policies are evaluated before every mutating call to the backend AWS
+	// service API
+	policies []acktypes.Policy
+	// hooks are called around the methods of the resource managers. It's nil
+	// if no hooks are registered for the kind of resource.
+	hooks *acktypes.ResourceManagerHooks
+	// events receives AWS resource change notifications and enqueues the CRs
+	// they name. It's nil if notifications aren't received.
+	events *eventSource
+	// scheme is the controller manager's scheme, with which the Kubernetes
+	// API types of all the resources of the AWS service API are registered
+	scheme *k8sruntime.Scheme
+	// related contains the descriptors of all the kinds of resources of the
+	// AWS service API, keyed by kind. It's used to find the parent and child
+	// resources of a resource.
+	related map[string]acktypes.AWSResourceDescriptor
 }
 
 // GroupKind returns the string containing the API group and kind reconciled by
@@ -69,32 +124,97 @@ func (r *reconciler) BindControllerManager(mgr ctrlrt.Manager) error {
 		return err
 	}
 	r.kc = mgr.GetClient()
-	r.cache = ackrtcache.New(clientset, r.log)
+	r.scheme = mgr.GetScheme()
+	r.recorder = mgr.GetEventRecorderFor(r.rd.GroupKind().Group)
+	r.refreshConfig()
+	r.cache = ackrtcache.New(clientset, r.log, r.cfg.namespaceFilter())
+	if err = mgr.Add(&r.cache); err != nil {
+		return err
+	}
+	if r.cfg.OrphanScanPeriod.Duration > 0 {
+		if err = mgr.Add(newOrphanScanner(r)); err != nil {
+			return err
+		}
+	}
+	if r.cfg.DiscoveryNamespace != "" {
+		if err = mgr.Add(newDiscoverer(r)); err != nil {
+			return err
+		}
+	}
 	rd := r.rmf.ResourceDescriptor()
-	return ctrlrt.NewControllerManagedBy(
+	builder := ctrlrt.NewControllerManagedBy(
 		mgr,
 	).For(
 		rd.EmptyRuntimeObject(),
-	).Complete(r)
+	)
+	empty := rd.ResourceFromRuntimeObject(rd.EmptyRuntimeObject())
+	if _, ok := empty.(acktypes.SecretReferencingAWSResource); ok {
+		// CRs referring to a Secret are reconciled when the Secret changes,
+		// so that the new value is applied to the backend AWS service API
+		// resource
+		secretHandler, err := r.watchSecrets(mgr)
+		if err != nil {
+			return err
+		}
+		builder = builder.Watches(
+			&source.Kind{Type: &corev1.Secret{}}, secretHandler,
+		)
+	}
+	if r.events != nil {
+		// CRs are reconciled right away when a notification about their
+		// backend AWS service API resource is received
+		notifications, err := r.events.watch(mgr, r)
+		if err != nil {
+			return err
+		}
+		builder = builder.Watches(notifications, &handler.EnqueueRequestForObject{})
+	}
+	return builder.WithOptions(controller.Options{
+		RateLimiter: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(
+				minFailureRequeueDelay, maxFailureRequeueDelay,
+			),
+			r.limiter,
+		),
+	}).Complete(r)
 }
 
-// SecretValueFromReference fetches the value of a Secret given a
-// SecretReference
-func (r *reconciler) SecretValueFromReference(
-	ref *corev1.SecretReference,
-) (string, error) {
-	// TODO(alina-kim): Implement this method :)
-	return "", ackerr.NotImplemented
+// refreshConfig updates the reconciler's configuration, and the limit on the
+// rate of reconciliations, with the current configuration for the kind of
+// resource it reconciles
+func (r *reconciler) refreshConfig() {
+	r.cfg = r.live.forGroupKind(r.rd.GroupKind())
+	limit := rate.Inf
+	if r.cfg.ReconcileRateLimit > 0 {
+		limit = rate.Limit(r.cfg.ReconcileRateLimit)
+	}
+	r.limiter.set(limit, r.cfg.ReconcileRateBurst)
 }
 
 // Reconcile implements `controller-runtime.Reconciler` and handles reconciling
 // a CR CRUD request
 func (r *reconciler) Reconcile(req ctrlrt.Request) (ctrlrt.Result, error) {
-	return r.handleReconcileError(r.reconcile(req))
+	r.refreshConfig()
+	return r.handleReconcileError(req, r.reconcile(req))
 }
 
 func (r *reconciler) reconcile(req ctrlrt.Request) error {
 	ctx := context.Background()
+	if !r.cache.Namespaces.HasSynced() {
+		// The namespace annotations and the namespaces that are ignored
+		// aren't known yet
+		return requeue.NeededAfter(
+			errors.New("namespace cache has not synced"), namespaceCacheSyncDelay,
+		)
+	}
+	if r.cache.Namespaces.IsIgnored(req.Namespace) {
+		r.log.V(1).Info(
+			"ignoring resource in ignored namespace",
+			"namespace", req.Namespace,
+			"name", req.Name,
+		)
+		return nil
+	}
 	res, err := r.getAWSResource(ctx, req)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -106,6 +226,11 @@ func (r *reconciler) reconcile(req ctrlrt.Request) error {
 
 	acctID := r.getOwnerAccountID(res)
 	region := r.getRegion(res)
+	endpointURL := r.getEndpointURL(res)
+	creds, err := r.getCredentialsSource(res)
+	if err != nil {
+		return err
+	}
 
 	r.log = r.log.WithValues(
 		"account_id", acctID,
@@ -114,11 +239,23 @@ func (r *reconciler) reconcile(req ctrlrt.Request) error {
 	)
 	r.log.V(1).Info("starting reconcilation")
 
-	rm, err := r.rmf.ManagerFor(r, acctID, region)
+	sess, err := r.getSession(region, endpointURL, creds)
+	if err != nil {
+		return err
+	}
+	rm, err := r.rmf.ManagerFor(r, acctID, region, sess)
 	if err != nil {
 		return err
 	}
+	if r.hooks != nil {
+		rm = NewHookedResourceManager(rm, r.hooks)
+	}
 
+	ctx = r.withAuditInfo(ctx, res, acctID, region)
+	ctx = withSecretNamespace(ctx, req.Namespace)
+	if r.isReconcilePaused(res) {
+		return r.pause(ctx, rm, res)
+	}
 	if res.IsBeingDeleted() {
 		return r.cleanup(ctx, rm, res)
 	}
@@ -134,21 +271,62 @@ func (r *reconciler) sync(
 	desired acktypes.AWSResource,
 ) error {
 	var latest acktypes.AWSResource // the newly created or mutated resource
+	// driftedPaths are the paths of the Spec fields of the latest observed
+	// state that differ from the desired state, when the drift policy is
+	// report
+	var driftedPaths []string
+	// waitingFor are the resources that the resource depends on, which must
+	// be synced before the resource is created or updated
+	var waitingFor []Dependency
 
 	isAdopted := IsAdopted(desired)
 
-	// TODO(jaypipes): Validate all dependent resources. The AWSResource
-	// interface needs to get some methods that return schema relationships,
-	// first though
+	// The CRs of child resources are owned by their parent's CR, so that
+	// they're garbage collected along with it
+	parentDeleted, err := r.ensureParentReference(ctx, desired)
+	if err != nil {
+		return err
+	}
+
+	// The default tags are added to the resource when it's created and must
+	// not be removed when the resource's tags are synced, so we merge them
+	// into the desired state before comparing it to the latest observed state
+	r.ensureDefaultTags(desired)
+
+	// The hash of the current values of the Secret keys the resource refers
+	// to tells whether they changed since they were last applied
+	secretsHash, err := r.secretsHash(ctx, desired)
+	if err != nil {
+		return err
+	}
+
+	unsynced, err := r.unsyncedDependencies(ctx, desired)
+	if err != nil {
+		return r.handleDependencyError(ctx, desired, err)
+	}
 
-	latest, err := rm.ReadOne(ctx, desired)
+	latest, err = rm.ReadOne(ctx, desired)
 	if err != nil {
 		if err != ackerr.NotFound {
-			return err
+			return ackerr.Wrap(err, r.rd.GroupKind(), "ReadOne")
 		}
 		if isAdopted {
 			return ackerr.AdoptedResourceNotFound
 		}
+		if parentDeleted {
+			// The backend AWS service API resource was deleted along with
+			// its parent's and cannot be created again
+			return r.handleParentDeleted(ctx, desired)
+		}
+		if len(unsynced) > 0 {
+			return r.waitForDependencies(ctx, desired, unsynced)
+		}
+		err = r.evaluatePolicies(
+			ctx, acktypes.PolicyOperationCreate, desired, nil, nil,
+		)
+		if err != nil {
+			return r.handlePolicyError(ctx, desired, err)
+		}
 		// Before we create the backend AWS service resources, let's first mark
 		// the CR as being managed by ACK. Internally, this means adding a
 		// finalizer to the CR; a finalizer that is removed once ACK no longer
@@ -158,50 +336,95 @@ func (r *reconciler) sync(
 			return err
 		}
 
-		latest, err = rm.Create(ctx, desired)
+		// Patching the CR above replaced the desired state with the CR
+		// returned by the Kubernetes API server, which doesn't have the
+		// default tags
+		r.ensureDefaultTags(desired)
+		latest, err = rm.Create(withAuditAction(ctx, "Create"), desired)
 		if err != nil {
-			return err
+			return ackerr.Wrap(err, r.rd.GroupKind(), "Create")
 		}
+		setSecretsHash(latest, secretsHash)
 		r.log.V(0).Info(
 			"reconciler.sync created new resource",
 			"arn", latest.Identifiers().ARN(),
 		)
-	} else {
-		// Check to see if the latest observed state already matches the
-		// desired state and if so, simply return since there's nothing to do
-		if r.rd.Equal(desired, latest) {
-			return nil
+		// Record the identifiers of the new resource on the CR before doing
+		// anything else. If the service controller stops before the end of
+		// this sync, the next reconciliation finds the resource using these
+		// identifiers instead of creating a duplicate resource.
+		if err = r.patchResourceStatus(ctx, desired, latest); err != nil {
+			return err
 		}
+	} else if !isStable(latest) {
+		// The backend AWS service API resource is transitioning between
+		// states, or has failed, and cannot be modified until it's stable
+		r.log.V(1).Info(
+			"resource is not stable, skipping update",
+			"state", latest.(acktypes.StatefulAWSResource).State(),
+			"arn", latest.Identifiers().ARN(),
+		)
+	} else if !r.rd.Equal(desired, latest) || secretsChanged(desired, secretsHash) {
+		// The latest observed state doesn't match the desired state, or the
+		// values of the Secrets the resource refers to changed, so we need
+		// to update the resource
 		diffReporter := r.rd.Diff(desired, latest)
+		if secretsChanged(desired, secretsHash) {
+			addSecretDifferences(diffReporter, desired)
+		}
 		r.log.V(1).Info(
 			"desired resource state has changed",
 			"diff", diffReporter.String(),
 			"arn", latest.Identifiers().ARN(),
 			"is_adopted", isAdopted,
 		)
-		latest, err = rm.Update(ctx, desired, latest, diffReporter)
-		if err != nil {
-			return err
+		if r.driftPolicy(desired) == ackv1alpha1.DriftPolicyReport {
+			// The resource is left as is and only the differences are
+			// reported
+			driftedPaths = DriftedPaths(diffReporter)
+		} else if len(unsynced) > 0 {
+			// The resource is left as is until the resources it depends on
+			// are synced
+			waitingFor = unsynced
+		} else {
+			err = r.evaluatePolicies(
+				ctx, acktypes.PolicyOperationUpdate, desired, latest, diffReporter,
+			)
+			if err != nil {
+				return r.handlePolicyError(ctx, desired, err)
+			}
+			latest, err = rm.Update(
+				withAuditAction(ctx, "Update"), desired, latest, diffReporter,
+			)
+			if err != nil {
+				return ackerr.Wrap(err, r.rd.GroupKind(), "Update")
+			}
+			setSecretsHash(latest, secretsHash)
+			r.log.V(0).Info("reconciler.sync updated resource")
 		}
-		r.log.V(0).Info("reconciler.sync updated resource")
+	}
+	seedSecretsHash(latest, secretsHash)
+	r.resume(latest)
+	r.setSyncConditions(latest)
+	r.setDriftConditions(desired, latest, driftedPaths)
+	r.setDependencyConditions(latest, waitingFor)
+	// Check to see if the latest observed state, including the conditions,
+	// already matches the desired state and if so, there's no need to patch
+	// the CR
+	if r.rd.Equal(desired, latest) {
+		return requeueIfWaiting(latest)
 	}
 	changedStatus, err := r.rd.UpdateCRStatus(latest)
 	if err != nil {
 		return err
 	}
 	if !changedStatus {
-		return nil
+		return requeueIfWaiting(latest)
 	}
-	err = r.kc.Status().Patch(
-		ctx,
-		latest.RuntimeObject(),
-		client.MergeFrom(desired.RuntimeObject()),
-	)
-	if err != nil {
+	if err = r.patchResourceStatus(ctx, desired, latest); err != nil {
 		return err
 	}
-	r.log.V(1).Info("patched CR status")
-	return err
+	return requeueIfWaiting(latest)
 }
 
 // cleanup ensures that the supplied AWSResource's backing API resource is
@@ -211,26 +434,90 @@ func (r *reconciler) cleanup(
 	rm acktypes.AWSResourceManager,
 	current acktypes.AWSResource,
 ) error {
-	// TODO(jaypipes): Handle all dependent resources. The AWSResource
-	// interface needs to get some methods that return schema relationships,
-	// first though
 	observed, err := rm.ReadOne(ctx, current)
 	if err != nil {
 		if err == ackerr.NotFound {
 			// If the aws resource is not found, remove finalizer
 			return r.setResourceUnmanaged(ctx, current)
 		}
-		return err
+		return ackerr.Wrap(err, r.rd.GroupKind(), "ReadOne")
 	}
-	if err = rm.Delete(ctx, observed); err != nil {
-		return err
+	// Some AWS service APIs reject a Delete operation for a resource that is
+	// already being deleted, so we only call Delete once
+	if !isDeleting(observed) {
+		// The CRs of the child resources are deleted first, so that their
+		// backend AWS service API resources are cleaned up in order rather
+		// than implicitly deleted along with the resource
+		if err = r.deleteChildren(ctx, current); err != nil {
+			return err
+		}
+		err = r.evaluatePolicies(
+			ctx, acktypes.PolicyOperationDelete, current, observed, nil,
+		)
+		if err != nil {
+			return r.handlePolicyError(ctx, current, err)
+		}
+		if err = rm.Delete(withAuditAction(ctx, "Delete"), observed); err != nil {
+			return ackerr.Wrap(err, r.rd.GroupKind(), "Delete")
+		}
+		r.log.V(0).Info("reconciler.cleanup deleted resource")
 	}
-	r.log.V(0).Info("reconciler.cleanup deleted resource")
 
-	// Now that external AWS service resources have been appropriately cleaned
-	// up, we remove the finalizer representing the CR is managed by ACK,
-	// allowing the CR to be deleted by the Kubernetes API server
-	return r.setResourceUnmanaged(ctx, observed)
+	// Many AWS service APIs delete resources asynchronously, so we check that
+	// the resource is actually gone before removing the finalizer. Otherwise
+	// the CR is removed while the backend AWS service resource still exists.
+	latest, err := rm.ReadOne(ctx, observed)
+	if err != nil {
+		if err == ackerr.NotFound {
+			// Now that external AWS service resources have been
+			// appropriately cleaned up, we remove the finalizer representing
+			// the CR is managed by ACK, allowing the CR to be deleted by the
+			// Kubernetes API server
+			return r.setResourceUnmanaged(ctx, current)
+		}
+		return ackerr.Wrap(err, r.rd.GroupKind(), "ReadOne")
+	}
+	SetCondition(
+		latest, ackv1alpha1.ConditionTypeDeleting,
+		corev1.ConditionTrue, "waiting for resource to be deleted",
+	)
+	deleting := GetCondition(latest, ackv1alpha1.ConditionTypeDeleting)
+	if r.cfg.DeletionTimeout.Duration > 0 && deleting.LastTransitionTime != nil &&
+		time.Since(deleting.LastTransitionTime.Time) > r.cfg.DeletionTimeout.Duration {
+		r.log.Info(
+			"giving up waiting for resource to be deleted",
+			"arn", latest.Identifiers().ARN(),
+			"timeout", r.cfg.DeletionTimeout.Duration,
+		)
+		return r.setResourceUnmanaged(ctx, current)
+	}
+	if !r.rd.Equal(current, latest) {
+		if err = r.patchResourceStatus(ctx, current, latest); err != nil {
+			return err
+		}
+	}
+	return requeue.NeededWithBackoff(
+		ackerr.ResourceBeingDeleted, requeue.DefaultBackoff,
+	)
+}
+
+// patchResourceStatus patches the Status of the underlying CR in the supplied
+// AWSResource to match the latest observed state of the resource
+func (r *reconciler) patchResourceStatus(
+	ctx context.Context,
+	orig acktypes.AWSResource,
+	latest acktypes.AWSResource,
+) error {
+	err := r.kc.Status().Patch(
+		ctx,
+		latest.RuntimeObject(),
+		client.MergeFrom(orig.RuntimeObject()),
+	)
+	if err != nil {
+		return err
+	}
+	r.log.V(1).Info("patched CR status")
+	return nil
 }
 
 // setResourceManaged marks the underlying CR in the supplied AWSResource with
@@ -281,6 +568,20 @@ func (r *reconciler) setResourceUnmanaged(
 	return nil
 }
 
+// recordEvent emits a Kubernetes event about the CR in the supplied
+// AWSResource
+func (r *reconciler) recordEvent(
+	res acktypes.AWSResource,
+	eventType string,
+	reason string,
+	message string,
+) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Event(res.RuntimeObject(), eventType, reason, message)
+}
+
 // getAWSResource returns an AWSResource representing the requested Kubernetes
 // namespaced object
 func (r *reconciler) getAWSResource(
@@ -295,15 +596,48 @@ func (r *reconciler) getAWSResource(
 }
 
 // handleReconcileError will handle errors from reconcile handlers, which
-// respects runtime errors.
-func (r *reconciler) handleReconcileError(err error) (ctrlrt.Result, error) {
+// respects runtime errors. Successfully reconciled CRs are requeued after the
+// configured resync period, if any.
+//
+// Requeue delays computed by a backoff use the number of consecutive
+// requeues of the CR with a backoff, which is reset by any other outcome. A
+// Retry-After hint from the AWS service API takes precedence over shorter
+// delays.
+func (r *reconciler) handleReconcileError(
+	req ctrlrt.Request,
+	err error,
+) (ctrlrt.Result, error) {
+	key := req.NamespacedName.String()
+	var requeueNeededWithBackoff *requeue.RequeueNeededWithBackoff
+	if !errors.As(err, &requeueNeededWithBackoff) {
+		r.attempts.Reset(key)
+	}
 	if err == nil {
-		return ctrlrt.Result{}, nil
+		return ctrlrt.Result{RequeueAfter: r.cfg.ResyncPeriod.Duration}, nil
+	}
+	retryAfter, hasRetryAfter := requeue.RetryAfter(err)
+
+	if requeueNeededWithBackoff != nil {
+		attempt := r.attempts.Next(key)
+		after := requeueNeededWithBackoff.Backoff().Delay(attempt)
+		if hasRetryAfter && retryAfter > after {
+			after = retryAfter
+		}
+		r.log.V(1).Info(
+			"requeue needed with backoff after error",
+			"error", requeueNeededWithBackoff.Unwrap(),
+			"attempt", attempt,
+			"after", after,
+		)
+		return ctrlrt.Result{RequeueAfter: after}, nil
 	}
 
 	var requeueNeededAfter *requeue.RequeueNeededAfter
 	if errors.As(err, &requeueNeededAfter) {
 		after := requeueNeededAfter.Duration()
+		if hasRetryAfter && retryAfter > after {
+			after = retryAfter
+		}
 		r.log.V(1).Info(
 			"requeue needed after error",
 			"error", requeueNeededAfter.Unwrap(),
@@ -321,6 +655,10 @@ func (r *reconciler) handleReconcileError(err error) (ctrlrt.Result, error) {
 		return ctrlrt.Result{Requeue: true}, nil
 	}
 
+	if hasRetryAfter {
+		r.log.Error(err, "requeue after delay requested by AWS service API", "after", retryAfter)
+		return ctrlrt.Result{RequeueAfter: retryAfter}, nil
+	}
 	return ctrlrt.Result{}, err
 }
 
@@ -369,16 +707,89 @@ func (r *reconciler) getRegion(
 	return ackv1alpha1.AWSRegion(r.cfg.Region)
 }
 
-// NewReconciler returns a new reconciler object that
+// getEndpointURL returns the URL of the AWS service API endpoint to call to
+// manage the backend AWS service API resource for the given resource, or the
+// empty string if the default endpoint for the resource's region should be
+// called. If the resource's namespace has an endpoint URL associated with it,
+// it is used. Otherwise, the endpoint URL for the service API specified in the
+// configuration is used, followed by the endpoint URL for all service APIs
+// specified in the configuration.
+func (r *reconciler) getEndpointURL(
+	res acktypes.AWSResource,
+) string {
+	// look for endpoint URL in namespace metadata annotations
+	ns := res.MetaObject().GetNamespace()
+	endpointURL, ok := r.cache.Namespaces.GetEndpointURL(ns)
+	if ok {
+		return endpointURL
+	}
+
+	// use controller configuration endpoint URL
+	return r.cfg.serviceEndpointURL(r.serviceAlias())
+}
+
+// defaultResourceManager returns the resource manager for the resources in
+// the AWS account and region of the supplied configuration, which uses the
+// AWS service API endpoint and the credentials provider from the
+// configuration
+func (r *reconciler) defaultResourceManager(
+	cfg Config,
+) (acktypes.AWSResourceManager, error) {
+	region := ackv1alpha1.AWSRegion(cfg.Region)
+	// Config.Validate() ensures the flag value is well-formed
+	creds, _ := parseCredentialsProvider(
+		cfg.CredentialsProvider, ackrtcache.CurrentNamespace(),
+	)
+	sess, err := r.getSession(
+		region, cfg.serviceEndpointURL(r.serviceAlias()), creds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r.rmf.ManagerFor(
+		r, ackv1alpha1.AWSAccountID(cfg.AccountID), region, sess,
+	)
+}
+
+// serviceAlias returns the alias of the AWS service API of the resources
+// reconciled by the reconciler, which is the first part of their API group,
+// e.g. "s3" for "s3.services.k8s.aws"
+func (r *reconciler) serviceAlias() string {
+	return strings.SplitN(r.rd.GroupKind().Group, ".", 2)[0]
+}
+
+// NewReconciler returns a new reconciler object that reconciles the kind of
+// resource managed by the supplied resource manager factory. The AWS service
+// API calls made to create, update and delete resources are recorded in the
+// supplied AuditSink, unless it's nil.
 func NewReconciler(
 	rmf acktypes.AWSResourceManagerFactory,
 	log logr.Logger,
 	cfg Config,
+	audit AuditSink,
 ) acktypes.AWSResourceReconciler {
+	return newReconciler(rmf, log, newLiveConfig(cfg, nil, log), audit)
+}
+
+// newReconciler returns a new reconciler object whose configuration is read
+// from the supplied liveConfig, which may be shared with other reconcilers
+func newReconciler(
+	rmf acktypes.AWSResourceManagerFactory,
+	log logr.Logger,
+	live *liveConfig,
+	audit AuditSink,
+) *reconciler {
+	rd := rmf.ResourceDescriptor()
+	cfg := live.forGroupKind(rd.GroupKind())
 	return &reconciler{
-		rmf: rmf,
-		rd:  rmf.ResourceDescriptor(),
-		log: log,
-		cfg: cfg,
+		rmf:      rmf,
+		rd:       rd,
+		log:      log,
+		cfg:      cfg,
+		live:     live,
+		limiter:  &bucketRateLimiter{},
+		attempts: requeue.NewAttempts(),
+		sessions: map[sessionKey]*session.Session{},
+		audit:    audit,
 	}
 }
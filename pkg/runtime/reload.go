@@ -0,0 +1,210 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+
+	ackrtcache "github.com/aws/aws-controllers-k8s/pkg/runtime/cache"
+)
+
+const (
+	// configFilePollPeriod is how often the configuration file is checked
+	// for changes
+	configFilePollPeriod = 10 * time.Second
+)
+
+// liveConfig holds the service controller's Config and applies changes made
+// to the configuration file and the ConfigMap while the service controller is
+// running. Only the settings documented on Config as reloadable are changed;
+// changes to other settings take effect on the next restart.
+//
+// liveConfig implements the upstream controller-runtime `manager.Runnable`
+// interface, watching the configuration file and ConfigMap until the manager
+// is stopped.
+type liveConfig struct {
+	sync.RWMutex
+	log       logr.Logger
+	cfg       Config
+	clientset kubernetes.Interface
+}
+
+// newLiveConfig returns a liveConfig holding the supplied Config. The supplied
+// clientset is used to watch the ConfigMap and may be nil if the Config
+// doesn't name one.
+func newLiveConfig(
+	cfg Config,
+	clientset kubernetes.Interface,
+	log logr.Logger,
+) *liveConfig {
+	return &liveConfig{
+		log:       log.WithName("config"),
+		cfg:       *cfg.DeepCopy(),
+		clientset: clientset,
+	}
+}
+
+// forGroupKind returns the current Config with the overrides for the supplied
+// GroupKind applied
+func (c *liveConfig) forGroupKind(gk *metav1.GroupKind) Config {
+	c.RLock()
+	defer c.RUnlock()
+	return c.cfg.forGroupKind(gk)
+}
+
+// NeedLeaderElection implements the upstream controller-runtime
+// `manager.LeaderElectionRunnable` interface. Every replica of the service
+// controller reloads its configuration, not just the leader.
+func (c *liveConfig) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements the upstream controller-runtime `manager.Runnable`
+// interface and watches the configuration file and the ConfigMap until the
+// supplied channel is closed
+func (c *liveConfig) Start(stopCh <-chan struct{}) error {
+	if c.cfg.ConfigMap != "" && c.clientset != nil {
+		ns, name := c.cfg.configMapName()
+		ackrtcache.NewConfigCache(
+			c.clientset, ns, name, c.log,
+			func(data map[string]string) {
+				c.setConfigMapData([]byte(data[ConfigMapKey]))
+			},
+		).Run(stopCh)
+	}
+	if c.cfg.ConfigFile == "" {
+		<-stopCh
+		return nil
+	}
+	ticker := time.NewTicker(configFilePollPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case <-ticker.C:
+			data, err := ioutil.ReadFile(c.cfg.ConfigFile)
+			if err != nil {
+				c.log.Error(err, "unable to read configuration file")
+				continue
+			}
+			c.setFileData(data)
+		}
+	}
+}
+
+// setFileData reloads the configuration if the supplied contents of the
+// configuration file have changed
+func (c *liveConfig) setFileData(data []byte) {
+	c.Lock()
+	defer c.Unlock()
+	if bytes.Equal(c.cfg.fileData, data) {
+		return
+	}
+	c.cfg.fileData = data
+	c.reload()
+}
+
+// setConfigMapData reloads the configuration if the supplied contents of the
+// ConfigMap have changed
+func (c *liveConfig) setConfigMapData(data []byte) {
+	c.Lock()
+	defer c.Unlock()
+	if bytes.Equal(c.cfg.configMapData, data) {
+		return
+	}
+	c.cfg.configMapData = data
+	c.reload()
+}
+
+// reload reads the configuration file and ConfigMap contents over the flag
+// values again and applies the reloadable settings. An invalid configuration
+// is logged and ignored. The caller must hold the write lock.
+func (c *liveConfig) reload() {
+	newCfg := c.cfg.DeepCopy()
+	if c.cfg.flagValues != nil {
+		newCfg = c.cfg.flagValues.DeepCopy()
+	}
+	if err := newCfg.unmarshal(c.cfg.fileData); err != nil {
+		c.log.Error(err, "ignoring invalid configuration file")
+		return
+	}
+	if err := newCfg.unmarshal(c.cfg.configMapData); err != nil {
+		c.log.Error(err, "ignoring invalid configuration ConfigMap")
+		return
+	}
+	if err := newCfg.Validate(); err != nil {
+		c.log.Error(err, "ignoring invalid configuration")
+		return
+	}
+	c.cfg.LogLevel = newCfg.LogLevel
+	c.cfg.DeletionTimeout = newCfg.DeletionTimeout
+	c.cfg.ReadWhilePaused = newCfg.ReadWhilePaused
+	c.cfg.ResyncPeriod = newCfg.ResyncPeriod
+	c.cfg.ReconcileRateLimit = newCfg.ReconcileRateLimit
+	c.cfg.ReconcileRateBurst = newCfg.ReconcileRateBurst
+	c.cfg.Resources = newCfg.Resources
+	setLogLevel(c.cfg.LogLevel)
+	c.log.Info("reloaded configuration")
+}
+
+// bucketRateLimiter is a `workqueue.RateLimiter` that limits the overall rate
+// at which CRs are reconciled with a token bucket. Unlike
+// `workqueue.BucketRateLimiter`, its limit and burst can be changed while
+// the service controller is running.
+type bucketRateLimiter struct {
+	sync.Mutex
+	limiter *rate.Limiter
+}
+
+// set changes the limit and burst of the token bucket. The bucket is refilled
+// if either changes.
+func (l *bucketRateLimiter) set(limit rate.Limit, burst int) {
+	l.Lock()
+	defer l.Unlock()
+	if l.limiter != nil && l.limiter.Limit() == limit &&
+		l.limiter.Burst() == burst {
+		return
+	}
+	l.limiter = rate.NewLimiter(limit, burst)
+}
+
+// When implements `workqueue.RateLimiter` and returns how long to wait before
+// reconciling the supplied item
+func (l *bucketRateLimiter) When(item interface{}) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	if l.limiter == nil {
+		return 0
+	}
+	return l.limiter.Reserve().Delay()
+}
+
+// NumRequeues implements `workqueue.RateLimiter`. The token bucket doesn't
+// track individual items.
+func (l *bucketRateLimiter) NumRequeues(item interface{}) int {
+	return 0
+}
+
+// Forget implements `workqueue.RateLimiter`. The token bucket doesn't track
+// individual items.
+func (l *bucketRateLimiter) Forget(item interface{}) {}
@@ -0,0 +1,236 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8srt "k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+// secretIndexField is the name of the field index of CRs by the Secrets they
+// refer to. The indexed values are the "namespace/name" of the Secrets.
+const secretIndexField = "ack.secretReferences"
+
+// secretNamespaceContextKey is the key of the context value containing the
+// namespace of the reconciled CR, in which the Secrets it refers to are
+// looked up
+type secretNamespaceContextKey struct{}
+
+// withSecretNamespace returns a context in which Secrets are looked up in the
+// supplied namespace
+func withSecretNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, secretNamespaceContextKey{}, namespace)
+}
+
+// SecretValueFromReference fetches the value of the Secret key referred to by
+// the supplied SecretKeyReference. The Secret is looked up in the namespace of
+// the reconciled CR: CRs can't refer to the Secrets of other namespaces.
+func (r *reconciler) SecretValueFromReference(
+	ctx context.Context,
+	ref *ackv1alpha1.SecretKeyReference,
+) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	namespace, _ := ctx.Value(secretNamespaceContextKey{}).(string)
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: namespace, Name: ref.Name}
+	if err := r.kc.Get(ctx, key, secret); err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf(
+			"%w: %s in secret %s", ackerr.SecretKeyNotFound, ref.Key, key,
+		)
+	}
+	return string(value), nil
+}
+
+// SecretsHash returns a hash of the supplied Secret values, keyed by the path
+// of the Spec field referring to them. It's recorded in the Status of CRs to
+// detect changes to the values of the Secrets they refer to. CRs that don't
+// refer to any Secret get a hash too, so that an empty hash always means no
+// hash was recorded yet.
+func SecretsHash(values map[string]string) string {
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, path := range paths {
+		// Prefixing the lengths prevents different paths and values from
+		// being hashed as the same bytes
+		value := values[path]
+		fmt.Fprintf(h, "%d:%s%d:%s", len(path), path, len(value), value)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// secretsHash returns the hash of the current values of the Secret keys the
+// supplied resource refers to, or the empty string if it doesn't refer to
+// any
+func (r *reconciler) secretsHash(
+	ctx context.Context,
+	res acktypes.AWSResource,
+) (string, error) {
+	sr, ok := res.(acktypes.SecretReferencingAWSResource)
+	if !ok {
+		return "", nil
+	}
+	values := map[string]string{}
+	for path, ref := range sr.SecretReferences() {
+		value, err := r.SecretValueFromReference(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		values[path] = value
+	}
+	return SecretsHash(values), nil
+}
+
+// secretsChanged returns true if the supplied hash of the current values of
+// the Secret keys the supplied resource refers to differs from the hash of
+// the values last applied to the backend AWS service API resource. A resource
+// without a recorded hash, e.g. one created before it could refer to Secrets,
+// hasn't changed; its hash is seeded instead.
+func secretsChanged(res acktypes.AWSResource, hash string) bool {
+	sr, ok := res.(acktypes.SecretReferencingAWSResource)
+	return ok && sr.SecretsHash() != "" && sr.SecretsHash() != hash
+}
+
+// addSecretDifferences adds the Spec fields of the supplied resource that
+// refer to Secret keys to the supplied Reporter. The backend AWS service API
+// doesn't return sensitive values, so changes to them aren't found by
+// comparing the desired and latest observed states of the resource.
+func addSecretDifferences(
+	diff *ackcompare.Reporter,
+	res acktypes.AWSResource,
+) {
+	sr, ok := res.(acktypes.SecretReferencingAWSResource)
+	if !ok {
+		return
+	}
+	paths := []string{}
+	for path := range sr.SecretReferences() {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		diff.Differences = append(diff.Differences, ackcompare.DiffItem{
+			Path:   path,
+			ValueA: "<secret>",
+			ValueB: "<secret>",
+		})
+	}
+}
+
+// setSecretsHash records the supplied hash of the Secret values applied to
+// the backend AWS service API resource in the supplied resource
+func setSecretsHash(res acktypes.AWSResource, hash string) {
+	if sr, ok := res.(acktypes.SecretReferencingAWSResource); ok {
+		sr.SetSecretsHash(hash)
+	}
+}
+
+// seedSecretsHash records the supplied hash of the current Secret values in
+// the supplied resource if it has no recorded hash yet. The values are
+// assumed to be the ones applied to the backend AWS service API resource,
+// which is the best guess for a resource observed for the first time.
+func seedSecretsHash(res acktypes.AWSResource, hash string) {
+	if sr, ok := res.(acktypes.SecretReferencingAWSResource); ok {
+		if sr.SecretsHash() == "" {
+			sr.SetSecretsHash(hash)
+		}
+	}
+}
+
+// secretIndexValues returns the "namespace/name" of the Secrets the supplied
+// CR refers to, which are in the CR's namespace
+func (r *reconciler) secretIndexValues(obj k8srt.Object) []string {
+	sr, ok := r.rd.ResourceFromRuntimeObject(obj).(acktypes.SecretReferencingAWSResource)
+	if !ok {
+		return nil
+	}
+	values := []string{}
+	for _, ref := range sr.SecretReferences() {
+		values = append(values, k8stypes.NamespacedName{
+			Namespace: sr.MetaObject().GetNamespace(),
+			Name:      ref.Name,
+		}.String())
+	}
+	return values
+}
+
+// watchSecrets indexes the CRs reconciled by the reconciler by the Secrets
+// they refer to, and returns an event handler that enqueues the CRs referring
+// to a Secret when the Secret changes
+func (r *reconciler) watchSecrets(mgr ctrlrt.Manager) (handler.EventHandler, error) {
+	err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		r.rd.EmptyRuntimeObject(),
+		secretIndexField,
+		r.secretIndexValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+	index, err := newResourceIndex(
+		mgr.GetClient(), mgr.GetScheme(), r.rd.EmptyRuntimeObject(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	log := r.log
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+			secret := k8stypes.NamespacedName{
+				Namespace: o.Meta.GetNamespace(),
+				Name:      o.Meta.GetName(),
+			}
+			names, err := index.lookup(
+				context.Background(), secretIndexField, secret.String(),
+			)
+			if err != nil {
+				log.Error(
+					err, "unable to list resources referring to secret",
+					"secret", secret.String(),
+				)
+				return nil
+			}
+			reqs := make([]reconcile.Request, 0, len(names))
+			for _, name := range names {
+				reqs = append(reqs, reconcile.Request{NamespacedName: name})
+			}
+			return reqs
+		}),
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+)
+
+func TestSecretsHash(t *testing.T) {
+	require := require.New(t)
+
+	// Resources not referring to any Secret have a hash, so that the empty
+	// hash means none was recorded
+	require.NotEqual("", ackrt.SecretsHash(nil))
+	require.Equal(ackrt.SecretsHash(nil), ackrt.SecretsHash(map[string]string{}))
+
+	hash := ackrt.SecretsHash(map[string]string{
+		"Spec.AuthToken": "s3cr3t",
+		"Spec.Password":  "hunter2",
+	})
+	require.NotEqual("", hash)
+	require.NotContains(hash, "s3cr3t")
+	require.Equal(hash, ackrt.SecretsHash(map[string]string{
+		"Spec.Password":  "hunter2",
+		"Spec.AuthToken": "s3cr3t",
+	}))
+
+	// Changing a value changes the hash
+	require.NotEqual(hash, ackrt.SecretsHash(map[string]string{
+		"Spec.AuthToken": "s3cr3t",
+		"Spec.Password":  "hunter3",
+	}))
+
+	// Moving bytes between paths and values changes the hash
+	require.NotEqual(
+		ackrt.SecretsHash(map[string]string{"Spec.A": "BC"}),
+		ackrt.SecretsHash(map[string]string{"Spec.AB": "C"}),
+	)
+}
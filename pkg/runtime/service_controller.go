@@ -14,9 +14,12 @@
 package runtime
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
@@ -41,6 +44,19 @@ type ServiceController struct {
 	// log refers to the logr.Logger object handling logging for the service
 	// controller
 	log logr.Logger
+	// policies are evaluated by all the reconcilers before every mutating call
+	// to the backend AWS service API
+	policies []acktypes.Policy
+	// hooks is a map of the hooks called around the methods of resource
+	// managers, keyed by the GroupKind of the resources
+	hooks map[string]*acktypes.ResourceManagerHooks
+	// addToScheme registers the Kubernetes API types of the service with a
+	// scheme
+	addToScheme func(*k8sruntime.Scheme) error
+	// shared are the components shared by all the service controllers hosted
+	// by the controller manager, e.g. the event source and the audit sink.
+	// It's nil if the service controller creates its own components.
+	shared *managerComponents
 }
 
 // GetReconcilers returns a slice of types.AWSResourceReconcilers associated
@@ -57,6 +73,51 @@ func (c *ServiceController) WithLogger(log logr.Logger) *ServiceController {
 	return c
 }
 
+// WithSchemeBuilder sets up the service controller with the function that
+// registers the Kubernetes API types of the service with a scheme, e.g. the
+// `AddToScheme` function of the service's API package. NewManager registers
+// the types of all the service controllers it hosts.
+func (c *ServiceController) WithSchemeBuilder(
+	addToScheme func(*k8sruntime.Scheme) error,
+) *ServiceController {
+	c.addToScheme = addToScheme
+	return c
+}
+
+// WithPolicies sets the controller up to evaluate the supplied policies before
+// every mutating call to the backend AWS service API
+func (c *ServiceController) WithPolicies(
+	policies ...acktypes.Policy,
+) *ServiceController {
+	c.metaLock.Lock()
+	defer c.metaLock.Unlock()
+	c.policies = append(c.policies, policies...)
+	return c
+}
+
+// WithResourceManagerHooks sets the controller up to call the supplied hooks
+// around the methods of the resource managers for the supplied GroupKind.
+// Hooks registered for the same GroupKind are called in the order they were
+// registered.
+func (c *ServiceController) WithResourceManagerHooks(
+	gk *metav1.GroupKind,
+	hooks *acktypes.ResourceManagerHooks,
+) *ServiceController {
+	c.metaLock.Lock()
+	defer c.metaLock.Unlock()
+
+	if c.hooks == nil {
+		c.hooks = map[string]*acktypes.ResourceManagerHooks{}
+	}
+	existing, found := c.hooks[gk.String()]
+	if !found {
+		existing = &acktypes.ResourceManagerHooks{}
+		c.hooks[gk.String()] = existing
+	}
+	existing.Append(hooks)
+	return c
+}
+
 // WithResourceManagerFactories sets the controller up to manage resources with
 // a set of supplied factories
 func (c *ServiceController) WithResourceManagerFactories(
@@ -84,16 +145,76 @@ func (c *ServiceController) WithResourceManagerFactories(
 func (c *ServiceController) BindControllerManager(mgr ctrlrt.Manager, cfg Config) error {
 	c.metaLock.Lock()
 	defer c.metaLock.Unlock()
+	shared := c.shared
+	if shared == nil {
+		var err error
+		if shared, err = newManagerComponents(mgr, cfg, c.log); err != nil {
+			return err
+		}
+	}
+	policies := append([]acktypes.Policy{}, c.policies...)
+	if shared.policy != nil {
+		policies = append(policies, shared.policy)
+	}
+	related := make(
+		map[string]acktypes.AWSResourceDescriptor, len(c.rmFactories),
+	)
 	for _, rmf := range c.rmFactories {
-		rec := NewReconciler(rmf, c.log, cfg)
+		rd := rmf.ResourceDescriptor()
+		related[rd.GroupKind().Kind] = rd
+	}
+	enabledKinds := []string{}
+	disabledKinds := []string{}
+	for _, gk := range c.groupKinds() {
+		rmf := c.rmFactories[gk]
+		if !cfg.resourceEnabled(rmf.ResourceDescriptor().GroupKind()) {
+			disabledKinds = append(disabledKinds, gk)
+			continue
+		}
+		enabledKinds = append(enabledKinds, gk)
+		rec := newReconciler(rmf, c.log, shared.live, shared.audit)
+		rec.policies = policies
+		rec.hooks = c.hooks[rmf.ResourceDescriptor().GroupKind().String()]
+		rec.events = shared.events
+		rec.related = related
 		if err := rec.BindControllerManager(mgr); err != nil {
 			return err
 		}
+		if cfg.EnableWebhooks {
+			bindWebhooks(mgr.GetWebhookServer(), rmf.ResourceDescriptor())
+		}
 		c.reconcilers = append(c.reconcilers, rec)
 	}
+	c.log.Info(
+		"bound resource kinds",
+		"aws.service", c.ServiceAlias,
+		"enabled", enabledKinds,
+		"disabled", disabledKinds,
+	)
 	return nil
 }
 
+// groupKinds returns the sorted GroupKinds of the resources managed by the
+// resource manager factories
+func (c *ServiceController) groupKinds() []string {
+	gks := make([]string, 0, len(c.rmFactories))
+	for gk := range c.rmFactories {
+		gks = append(gks, gk)
+	}
+	sort.Strings(gks)
+	return gks
+}
+
+// resourceGroupKinds returns the GroupKinds of the resources managed by the
+// resource manager factories
+func (c *ServiceController) resourceGroupKinds() []*metav1.GroupKind {
+	gks := make([]*metav1.GroupKind, 0, len(c.rmFactories))
+	for _, gk := range c.groupKinds() {
+		gks = append(gks, c.rmFactories[gk].ResourceDescriptor().GroupKind())
+	}
+	return gks
+}
+
 // NewServiceController returns a new ServiceController instance
 func NewServiceController(
 	svcAlias string,
@@ -17,6 +17,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -37,6 +38,7 @@ import (
 	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 
 	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 )
 
 var (
@@ -91,6 +93,9 @@ func TestServiceController(t *testing.T) {
 	rd.On("EmptyRuntimeObject").Return(
 		&fakeBook{},
 	)
+	rd.On("ResourceFromRuntimeObject", mock.Anything).Return(
+		&mocks.AWSResource{},
+	)
 
 	rmf := &mocks.AWSResourceManagerFactory{}
 	rmf.On("ResourceDescriptor").Return(rd)
@@ -132,3 +137,64 @@ func TestServiceController(t *testing.T) {
 	require.True(foundfakeBookRecon)
 	rd.AssertCalled(t, "EmptyRuntimeObject")
 }
+
+func TestServiceControllerEnableResources(t *testing.T) {
+	require := require.New(t)
+
+	rmfFor := func(kind string) *mocks.AWSResourceManagerFactory {
+		rd := &mocks.AWSResourceDescriptor{}
+		rd.On("GroupKind").Return(
+			&metav1.GroupKind{
+				Group: "bookstore.services.k8s.aws",
+				Kind:  kind,
+			},
+		)
+		rd.On("EmptyRuntimeObject").Return(
+			&fakeBook{},
+		)
+		rd.On("ResourceFromRuntimeObject", mock.Anything).Return(
+			&mocks.AWSResource{},
+		)
+		rmf := &mocks.AWSResourceManagerFactory{}
+		rmf.On("ResourceDescriptor").Return(rd)
+		return rmf
+	}
+
+	zapOptions := ctrlrtzap.Options{
+		Development: true,
+		Level:       zapcore.InfoLevel,
+	}
+	fakeLogger := ctrlrtzap.New(ctrlrtzap.UseFlagOptions(&zapOptions))
+
+	for _, test := range []struct {
+		name     string
+		enable   []string
+		disable  []string
+		expected []string
+	}{
+		{"all by default", nil, nil, []string{"Author", "Book", "Shelf"}},
+		{"enable kinds", []string{"book", "Shelf.bookstore.services.k8s.aws"}, nil, []string{"Book", "Shelf"}},
+		{"disable kinds", nil, []string{"Author"}, []string{"Book", "Shelf"}},
+		{"disable takes precedence", []string{"Book", "Shelf"}, []string{"Book"}, []string{"Shelf"}},
+		{"unknown kinds", []string{"Magazine"}, nil, []string{}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sc := ackrt.NewServiceController("bookstore", "bookstore.services.k8s.aws")
+			sc.WithLogger(fakeLogger)
+			sc.WithResourceManagerFactories([]acktypes.AWSResourceManagerFactory{
+				rmfFor("Author"), rmfFor("Book"), rmfFor("Shelf"),
+			})
+			cfg := ackrt.Config{
+				EnableResources:  test.enable,
+				DisableResources: test.disable,
+			}
+			require.Nil(sc.BindControllerManager(&fakeManager{}, cfg))
+
+			kinds := []string{}
+			for _, recon := range sc.GetReconcilers() {
+				kinds = append(kinds, recon.GroupKind().Kind)
+			}
+			require.Equal(test.expected, kinds)
+		})
+	}
+}
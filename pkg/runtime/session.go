@@ -18,13 +18,36 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/aws/aws-controllers-k8s/pkg/requeue"
 )
 
-func NewSession(region ackv1alpha1.AWSRegion) (*session.Session, error) {
+// sessionKey identifies an AWS session that is shared by the resources
+// managed in the same AWS region, through the same AWS service API endpoint
+// and with the same AWS credentials
+type sessionKey struct {
+	region      ackv1alpha1.AWSRegion
+	endpointURL string
+	creds       credentialsSource
+}
+
+// NewSession returns a new AWS session for the supplied AWS region. If the
+// supplied endpoint URL is not empty, AWS service API clients created from the
+// session call that endpoint instead of the default endpoint for the region.
+func NewSession(
+	region ackv1alpha1.AWSRegion,
+	endpointURL string,
+) (*session.Session, error) {
 	awsCfg := aws.Config{
 		Region:              aws.String(string(region)),
 		STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
 	}
+	if endpointURL != "" {
+		awsCfg.Endpoint = aws.String(endpointURL)
+		// Local stand-ins for AWS service APIs generally don't support
+		// virtual-hosted-style S3 bucket addressing
+		awsCfg.S3ForcePathStyle = aws.Bool(true)
+	}
 	sess, err := session.NewSession(&awsCfg)
 	if err != nil {
 		return nil, err
@@ -32,3 +55,33 @@ func NewSession(region ackv1alpha1.AWSRegion) (*session.Session, error) {
 	// TODO(jaypipes): Handle throttling
 	return sess, nil
 }
+
+// getSession returns the AWS session used to manage resources in the supplied
+// AWS region, through the supplied AWS service API endpoint and with the
+// credentials from the supplied source. Sessions are created on first use and
+// then cached.
+func (r *reconciler) getSession(
+	region ackv1alpha1.AWSRegion,
+	endpointURL string,
+	creds credentialsSource,
+) (*session.Session, error) {
+	key := sessionKey{region, endpointURL, creds}
+	r.sessionsLock.Lock()
+	defer r.sessionsLock.Unlock()
+	if sess, found := r.sessions[key]; found {
+		return sess, nil
+	}
+	sess, err := NewSession(region, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+	if c := r.newCredentials(creds, sess); c != nil {
+		sess = sess.Copy(&aws.Config{Credentials: c})
+	}
+	sess.Handlers.Complete.PushBackNamed(requeue.RetryAfterHandler)
+	if r.audit != nil {
+		sess.Handlers.Complete.PushBackNamed(r.auditHandler())
+	}
+	r.sessions[key] = sess
+	return sess, nil
+}
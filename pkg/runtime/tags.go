@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+// TaggedOwner returns the namespace and name of the CR that the ownership
+// tags of the supplied resource claim represents it. It returns false if the
+// resource can't have tags or doesn't have the tags the service controller
+// adds to the resources it manages.
+func TaggedOwner(res acktypes.AWSResource) (k8stypes.NamespacedName, bool) {
+	taggable, ok := res.(acktypes.TaggableAWSResource)
+	if !ok {
+		return k8stypes.NamespacedName{}, false
+	}
+	tags := taggable.GetTags()
+	if tags[ackv1alpha1.TagKeyManaged] != "true" {
+		return k8stypes.NamespacedName{}, false
+	}
+	owner := k8stypes.NamespacedName{
+		Namespace: tags[ackv1alpha1.TagKeyNamespace],
+		Name:      tags[ackv1alpha1.TagKeyName],
+	}
+	if owner.Namespace == "" || owner.Name == "" {
+		return k8stypes.NamespacedName{}, false
+	}
+	return owner, true
+}
+
+// parseTags returns a map of tag keys to tag values from the supplied slice
+// of strings in the form key=value
+func parseTags(pairs []string) (map[string]string, error) {
+	tags, err := parseKeyValuePairs(pairs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag: %v", err)
+	}
+	return tags, nil
+}
+
+// defaultTags returns the tags that the service controller adds to the
+// supplied resource. In increasing order of precedence, these are the tags
+// supplied to the --resource-tags flag, the tags in the default resource tags
+// annotation on the resource's namespace and the tags identifying the CR that
+// represents the resource and, if the --cluster-id flag is set, its cluster.
+func (r *reconciler) defaultTags(
+	res acktypes.AWSResource,
+) map[string]string {
+	tags, err := parseTags(r.cfg.ResourceTags)
+	if err != nil {
+		// Config.Validate() ensures the flag values are well-formed, so we
+		// should never get here...
+		tags = map[string]string{}
+	}
+	ns := res.MetaObject().GetNamespace()
+	if nsTags, ok := r.cache.Namespaces.GetDefaultResourceTags(ns); ok {
+		parsed, err := parseTags(strings.Split(nsTags, ","))
+		if err != nil {
+			r.log.Info(
+				"ignoring invalid namespace annotation",
+				"annotation", ackv1alpha1.AnnotationDefaultResourceTags,
+				"namespace", ns,
+				"error", err,
+			)
+		}
+		for k, v := range parsed {
+			tags[k] = v
+		}
+	}
+	tags[ackv1alpha1.TagKeyManaged] = "true"
+	tags[ackv1alpha1.TagKeyNamespace] = ns
+	tags[ackv1alpha1.TagKeyName] = res.MetaObject().GetName()
+	if r.cfg.ClusterID != "" {
+		tags[ackv1alpha1.TagKeyClusterID] = r.cfg.ClusterID
+	}
+	return tags
+}
+
+// ensureDefaultTags merges the default tags into the tags of the supplied
+// resource, if the resource can have tags. Tags already set on the resource
+// take precedence over the default tags, except for the tags identifying the
+// CR that represents the resource.
+func (r *reconciler) ensureDefaultTags(
+	res acktypes.AWSResource,
+) {
+	taggable, ok := res.(acktypes.TaggableAWSResource)
+	if !ok {
+		return
+	}
+	tags := taggable.GetTags()
+	for k, v := range r.defaultTags(res) {
+		if _, found := tags[k]; found && !strings.HasPrefix(k, ackv1alpha1.TagKeyPrefix) {
+			continue
+		}
+		tags[k] = v
+	}
+	taggable.SetTags(tags)
+}
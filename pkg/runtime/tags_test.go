@@ -0,0 +1,60 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestTaggedOwner(t *testing.T) {
+	require := require.New(t)
+
+	taggedRes := func(tags map[string]string) *mocks.TaggableAWSResource {
+		res := &mocks.TaggableAWSResource{}
+		res.On("GetTags").Return(tags)
+		return res
+	}
+
+	owner, ok := ackrt.TaggedOwner(taggedRes(map[string]string{
+		ackv1alpha1.TagKeyManaged:   "true",
+		ackv1alpha1.TagKeyNamespace: "production",
+		ackv1alpha1.TagKeyName:      "my-repo",
+		"team":                      "storage",
+	}))
+	require.True(ok)
+	require.Equal(k8stypes.NamespacedName{Namespace: "production", Name: "my-repo"}, owner)
+
+	_, ok = ackrt.TaggedOwner(taggedRes(map[string]string{
+		ackv1alpha1.TagKeyNamespace: "production",
+		ackv1alpha1.TagKeyName:      "my-repo",
+	}))
+	require.False(ok)
+
+	_, ok = ackrt.TaggedOwner(taggedRes(map[string]string{
+		ackv1alpha1.TagKeyManaged:   "true",
+		ackv1alpha1.TagKeyNamespace: "production",
+	}))
+	require.False(ok)
+
+	_, ok = ackrt.TaggedOwner(&mocks.AWSResource{})
+	require.False(ok)
+}
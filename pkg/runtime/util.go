@@ -14,6 +14,9 @@
 package runtime
 
 import (
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
@@ -50,3 +53,21 @@ func IsSynced(res acktypes.AWSResource) bool {
 	}
 	return false
 }
+
+// parseKeyValuePairs returns a map of keys to values from the supplied slice
+// of strings in the form key=value
+func parseKeyValuePairs(pairs []string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%q is not in the form key=value", pair)
+		}
+		res[parts[0]] = parts[1]
+	}
+	return res, nil
+}
@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+)
+
+const (
+	// webhookPathPrefixMutate is the prefix of the path that the defaulting
+	// admission webhook for a resource kind is served on
+	webhookPathPrefixMutate = "mutate"
+	// webhookPathPrefixValidate is the prefix of the path that the validating
+	// admission webhook for a resource kind is served on
+	webhookPathPrefixValidate = "validate"
+)
+
+// webhookPath returns the path that the admission webhook with the supplied
+// prefix is served on for the supplied GroupKind, e.g.
+// "/validate-ecr-services-k8s-aws-repository". This must be kept in sync with
+// the webhook configuration manifests output by the code generator.
+func webhookPath(prefix string, gk *metav1.GroupKind) string {
+	return "/" + prefix + "-" + strings.Replace(gk.Group, ".", "-", -1) +
+		"-" + strings.ToLower(gk.Kind)
+}
+
+// bindWebhooks registers the defaulting and validating admission webhooks
+// for the kind of resource described by the supplied AWSResourceDescriptor
+// with the supplied webhook server
+func bindWebhooks(
+	srv *webhook.Server,
+	rd acktypes.AWSResourceDescriptor,
+) {
+	gk := rd.GroupKind()
+	srv.Register(
+		webhookPath(webhookPathPrefixMutate, gk),
+		&webhook.Admission{Handler: &defaultingWebhook{rd}},
+	)
+	srv.Register(
+		webhookPath(webhookPathPrefixValidate, gk),
+		&webhook.Admission{Handler: NewValidatingWebhook(rd)},
+	)
+}
+
+// NewValidatingWebhook returns an admission handler that rejects CRs of the
+// kind described by the supplied AWSResourceDescriptor whose Spec contains
+// values that the backend AWS service API would reject
+func NewValidatingWebhook(
+	rd acktypes.AWSResourceDescriptor,
+) admission.Handler {
+	return &validatingWebhook{rd}
+}
+
+// decodeAWSResource returns an AWSResource containing the supplied raw
+// object of an admission request
+func decodeAWSResource(
+	rd acktypes.AWSResourceDescriptor,
+	raw k8sruntime.RawExtension,
+) (acktypes.AWSResource, error) {
+	ro := rd.EmptyRuntimeObject()
+	if err := json.Unmarshal(raw.Raw, ro); err != nil {
+		return nil, err
+	}
+	return rd.ResourceFromRuntimeObject(ro), nil
+}
+
+// defaultingWebhook is an admission handler that sets default values on the
+// Spec of CRs of a single kind
+type defaultingWebhook struct {
+	rd acktypes.AWSResourceDescriptor
+}
+
+// Handle implements `admission.Handler` and returns a response containing
+// the patch that sets the default values on the CR in the supplied request
+func (w *defaultingWebhook) Handle(
+	ctx context.Context,
+	req admission.Request,
+) admission.Response {
+	res, err := decodeAWSResource(w.rd, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	w.rd.Default(res)
+	defaulted, err := json.Marshal(res.RuntimeObject())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+// validatingWebhook is an admission handler that rejects CRs of a single kind
+// whose Spec contains values that the backend AWS service API would reject
+type validatingWebhook struct {
+	rd acktypes.AWSResourceDescriptor
+}
+
+// Handle implements `admission.Handler` and returns a response that denies
+// the supplied request if the CR in the request is not valid.
+//
+// Only changes to the Spec are validated. The service controller adds and
+// removes its finalizer with updates that leave the Spec as is, which must
+// be allowed even if the CR was created before the validation rules were
+// tightened, or else the CR can never be deleted. CRs being deleted aren't
+// validated either.
+func (w *validatingWebhook) Handle(
+	ctx context.Context,
+	req admission.Request,
+) admission.Response {
+	if req.Operation == admissionv1beta1.Delete {
+		return admission.Allowed("")
+	}
+	res, err := decodeAWSResource(w.rd, req.Object)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if res.IsBeingDeleted() {
+		return admission.Allowed("")
+	}
+	if req.Operation == admissionv1beta1.Update {
+		old, err := decodeAWSResource(w.rd, req.OldObject)
+		if err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !w.rd.Diff(old, res).DifferentAt("Spec") {
+			return admission.Allowed("")
+		}
+	}
+	if err = w.rd.Validate(res); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8srt "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+
+	mocks "github.com/aws/aws-controllers-k8s/mocks/pkg/types"
+)
+
+func TestValidatingWebhook(t *testing.T) {
+	require := require.New(t)
+
+	// The name in the Spec of these Repositories is invalid
+	invalid := repositoryJSON(`{"name": "my-repo"}`)
+	finalized := repositoryJSON(`{
+		"name": "my-repo",
+		"finalizers": ["finalizers.ecr.services.k8s.aws/Repository"]
+	}`)
+	deleting := repositoryJSON(`{
+		"name": "my-repo",
+		"deletionTimestamp": "2020-10-01T00:00:00Z"
+	}`)
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("EmptyRuntimeObject").Return(func() k8srt.Object {
+		return &unstructured.Unstructured{}
+	})
+	rd.On("ResourceFromRuntimeObject", mock.Anything).Return(
+		func(ro k8srt.Object) acktypes.AWSResource {
+			u := ro.(*unstructured.Unstructured)
+			res := &mocks.AWSResource{}
+			res.On("IsBeingDeleted").Return(u.GetDeletionTimestamp() != nil)
+			res.On("MetaObject").Return(u)
+			return res
+		},
+	)
+	rd.On("Validate", mock.Anything).Return(errors.New("invalid name"))
+	// The finalizer is added without changing the Spec
+	rd.On("Diff", mock.Anything, mock.Anything).Return(&ackcompare.Reporter{})
+
+	wh := ackrt.NewValidatingWebhook(rd)
+	handle := func(op admissionv1beta1.Operation, obj, old string) admission.Response {
+		req := admission.Request{}
+		req.Operation = op
+		req.Object = k8srt.RawExtension{Raw: []byte(obj)}
+		if old != "" {
+			req.OldObject = k8srt.RawExtension{Raw: []byte(old)}
+		}
+		return wh.Handle(context.Background(), req)
+	}
+
+	// Invalid CRs are denied
+	require.False(handle(admissionv1beta1.Create, invalid, "").Allowed)
+
+	// The service controller can add and remove its finalizer from CRs that
+	// were valid when they were created
+	require.True(handle(admissionv1beta1.Update, finalized, invalid).Allowed)
+	require.True(handle(admissionv1beta1.Update, deleting, finalized).Allowed)
+	require.True(handle(admissionv1beta1.Delete, "", "").Allowed)
+}
+
+func TestValidatingWebhookSpecChange(t *testing.T) {
+	require := require.New(t)
+
+	rd := &mocks.AWSResourceDescriptor{}
+	rd.On("EmptyRuntimeObject").Return(func() k8srt.Object {
+		return &unstructured.Unstructured{}
+	})
+	rd.On("ResourceFromRuntimeObject", mock.Anything).Return(
+		func(ro k8srt.Object) acktypes.AWSResource {
+			res := &mocks.AWSResource{}
+			res.On("IsBeingDeleted").Return(false)
+			return res
+		},
+	)
+	rd.On("Validate", mock.Anything).Return(errors.New("invalid name"))
+	rd.On("Diff", mock.Anything, mock.Anything).Return(&ackcompare.Reporter{
+		Differences: []ackcompare.DiffItem{
+			{Path: "Spec.Name", ValueA: "my-repo", ValueB: "My Repo"},
+		},
+	})
+
+	req := admission.Request{}
+	req.Operation = admissionv1beta1.Update
+	req.Object = k8srt.RawExtension{
+		Raw: []byte(repositoryJSON(`{"name": "my-repo"}`)),
+	}
+	req.OldObject = k8srt.RawExtension{
+		Raw: []byte(repositoryJSON(`{"name": "my-repo", "generation": 1}`)),
+	}
+	resp := ackrt.NewValidatingWebhook(rd).Handle(context.Background(), req)
+	require.False(resp.Allowed)
+	require.Equal(int32(http.StatusForbidden), resp.Result.Code)
+}
+
+// repositoryJSON returns the JSON of an ECR Repository CR with the supplied
+// metadata and an invalid name in its Spec
+func repositoryJSON(metadata string) string {
+	return `{
+		"apiVersion": "ecr.services.k8s.aws/v1alpha1",
+		"kind": "Repository",
+		"metadata": ` + metadata + `,
+		"spec": {"repositoryName": "My Repo"}
+	}`
+}
@@ -38,6 +38,9 @@ type AWSResource interface {
 	Identifiers() AWSResourceIdentifiers
 	// Conditions returns the ACK Conditions collection for the AWSResource
 	Conditions() []*ackv1alpha1.Condition
+	// SetConditions replaces the ACK Conditions collection for the
+	// AWSResource
+	SetConditions([]*ackv1alpha1.Condition)
 	// IsBeingDeleted returns true if the Kubernetes resource has a non-zero
 	// deletion timestemp
 	IsBeingDeleted() bool
@@ -52,3 +55,90 @@ type AWSResource interface {
 	// apimachinery/apis/meta/v1.Object interfaces
 	RuntimeMetaObject() RuntimeMetaObject
 }
+
+// TaggableAWSResource is an AWSResource whose backend AWS service API resource
+// can have tags. The ACK runtime merges the default tags for all resources
+// created by the service controller into the tags of TaggableAWSResources.
+type TaggableAWSResource interface {
+	AWSResource
+	// GetTags returns the tags of the AWSResource as a map of tag keys to tag
+	// values
+	GetTags() map[string]string
+	// SetTags replaces the tags of the AWSResource with the supplied map of
+	// tag keys to tag values
+	SetTags(map[string]string)
+}
+
+// NamedAWSResource is an AWSResource whose backend AWS service API resource
+// has a name in addition to its ARN. The ACK runtime uses the name to create
+// CRs for existing resources discovered in the backend AWS service API.
+type NamedAWSResource interface {
+	AWSResource
+	// ResourceName returns the name of the backend AWS service API resource,
+	// or the empty string if the name isn't known
+	ResourceName() string
+	// SetResourceName sets the name of the backend AWS service API resource
+	SetResourceName(string)
+}
+
+// StatefulAWSResource is an AWSResource whose backend AWS service API resource
+// goes through transitional states, e.g. "creating" or "modifying", before it
+// reaches a stable state in which it can be used and modified.
+type StatefulAWSResource interface {
+	AWSResource
+	// State returns the current state of the backend AWS service API
+	// resource, e.g. "available" or "creating"
+	State() string
+	// IsStable returns true if the backend AWS service API resource is in a
+	// state in which it can be used and modified
+	IsStable() bool
+	// IsFailed returns true if the backend AWS service API resource is in a
+	// terminal state from which it will not recover, e.g. "create-failed"
+	IsFailed() bool
+}
+
+// SecretReferencingAWSResource is an AWSResource with Spec fields referring to
+// keys in Kubernetes Secrets instead of containing sensitive values. The ACK
+// runtime watches the referenced Secrets and updates the backend AWS service
+// API resource when the referenced values change.
+type SecretReferencingAWSResource interface {
+	AWSResource
+	// SecretReferences returns the references to Secret keys in the
+	// AWSResource's Spec, keyed by the path of the Spec field, e.g.
+	// "Spec.AuthToken". Fields that aren't set are omitted.
+	SecretReferences() map[string]*ackv1alpha1.SecretKeyReference
+	// SecretsHash returns the hash of the referenced Secret values that were
+	// last applied to the backend AWS service API resource, or the empty
+	// string if no hash was recorded yet
+	SecretsHash() string
+	// SetSecretsHash records the hash of the referenced Secret values applied
+	// to the backend AWS service API resource
+	SetSecretsHash(string)
+}
+
+// ParentAWSResource is an AWSResource whose backend AWS service API resource
+// contains the backend AWS service API resources of child resources of other
+// kinds, which are implicitly deleted along with it, e.g. an API Gateway API
+// and its Routes
+type ParentAWSResource interface {
+	AWSResource
+	// ChildKinds returns the kinds of the child resources, in the order
+	// they're deleted
+	ChildKinds() []string
+	// ChildIdentifier returns the identifier by which the child resources
+	// refer to the backend AWS service API resource, or the empty string if
+	// it isn't known
+	ChildIdentifier() string
+}
+
+// ChildAWSResource is an AWSResource whose backend AWS service API resource
+// is contained in the backend AWS service API resource of a parent resource
+// of another kind
+type ChildAWSResource interface {
+	AWSResource
+	// ParentKind returns the kind of the parent resource
+	ParentKind() string
+	// ParentIdentifier returns the identifier of the parent's backend AWS
+	// service API resource, or the empty string if it isn't known
+	ParentIdentifier() string
+}
@@ -65,4 +65,13 @@ type AWSResourceDescriptor interface {
 	// the resource. This will allow the Kubernetes API server to delete the
 	// underlying CR.
 	MarkUnmanaged(AWSResource)
+	// Default sets default values for any fields in the supplied
+	// AWSResource's Spec that have not been set by the Kubernetes user. It is
+	// called by the defaulting admission webhook for the resource's kind.
+	Default(AWSResource)
+	// Validate returns an error describing all the fields in the supplied
+	// AWSResource's Spec whose values are not acceptable to the backend AWS
+	// service API, or nil if the Spec is valid. It is called by the
+	// validating admission webhook for the resource's kind.
+	Validate(AWSResource) error
 }
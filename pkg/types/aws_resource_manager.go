@@ -15,6 +15,9 @@ package types
 
 import (
 	"context"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+
 	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 )
@@ -53,10 +56,20 @@ type AWSResourceManager interface {
 	// ARNFromName returns an AWS Resource Name from a given string name. This
 	// is useful for constructing ARNs for APIs that require ARNs in their
 	// GetAttributes operations but all we have (for new CRs at least) is a
-	// name for the resource
+	// name for the resource. It returns the empty string if the resource's
+	// ARN contains more than its name.
 	ARNFromName(string) string
 }
 
+// AWSResourceLister is implemented by the AWSResourceManagers that can list
+// all the resources of their kind in the backend AWS service API
+type AWSResourceLister interface {
+	// ReadMany returns the currently-observed state of all the resources of
+	// the resource manager's kind in the backend AWS service API, in the
+	// resource manager's AWS account and region
+	ReadMany(context.Context) ([]AWSResource, error)
+}
+
 // AWSResourceManagerFactory returns an AWSResourceManager that can be used to
 // manage AWS resources for a particular AWS account
 type AWSResourceManagerFactory interface {
@@ -66,10 +79,14 @@ type AWSResourceManagerFactory interface {
 	// prototypes
 	ResourceDescriptor() AWSResourceDescriptor
 	// ManagerFor returns an AWSResourceManager that manages AWS resources on
-	// behalf of a particular AWS account and in a specific AWS region
+	// behalf of a particular AWS account and in a specific AWS region, using
+	// the supplied AWS session to call the backend AWS service API. The
+	// session determines the AWS service API endpoint and the AWS credentials
+	// used.
 	ManagerFor(
 		AWSResourceReconciler,
 		ackv1alpha1.AWSAccountID,
 		ackv1alpha1.AWSRegion,
+		*session.Session,
 	) (AWSResourceManager, error)
 }
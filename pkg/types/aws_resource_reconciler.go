@@ -14,10 +14,13 @@
 package types
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlrt "sigs.k8s.io/controller-runtime"
 	ctrlreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 )
 
 // AWSResourceReconciler is responsible for reconciling the state of a SINGLE
@@ -38,7 +41,9 @@ type AWSResourceReconciler interface {
 	// BindControllerManager sets up the AWSResourceReconciler with an instance
 	// of an upstream controller-runtime.Manager
 	BindControllerManager(ctrlrt.Manager) error
-	// SecretValueFromReference fetches the value of a Secret given a
-	// SecretReference
-	SecretValueFromReference(*corev1.SecretReference) (string, error)
+	// SecretValueFromReference fetches the value of the Secret key referred
+	// to by the supplied SecretKeyReference
+	SecretValueFromReference(
+		context.Context, *ackv1alpha1.SecretKeyReference,
+	) (string, error)
 }
@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	"context"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+)
+
+// PreReadOneHook is called before AWSResourceManager.ReadOne with the
+// resource to read. Returning an error skips the call and returns the error.
+type PreReadOneHook func(
+	ctx context.Context,
+	res AWSResource,
+) error
+
+// PostReadOneHook is called after AWSResourceManager.ReadOne with the
+// resource that was read and the result of the call. It returns the result
+// passed to the next hook and, eventually, to the reconciler.
+type PostReadOneHook func(
+	ctx context.Context,
+	res AWSResource,
+	latest AWSResource,
+	err error,
+) (AWSResource, error)
+
+// PreCreateHook is called before AWSResourceManager.Create with the desired
+// resource. Returning an error skips the call and returns the error.
+type PreCreateHook func(
+	ctx context.Context,
+	desired AWSResource,
+) error
+
+// PostCreateHook is called after AWSResourceManager.Create with the desired
+// resource and the result of the call. It returns the result passed to the
+// next hook and, eventually, to the reconciler.
+type PostCreateHook func(
+	ctx context.Context,
+	desired AWSResource,
+	created AWSResource,
+	err error,
+) (AWSResource, error)
+
+// PreUpdateHook is called before AWSResourceManager.Update with the desired
+// and latest resources and their differences. Returning an error skips the
+// call and returns the error.
+type PreUpdateHook func(
+	ctx context.Context,
+	desired AWSResource,
+	latest AWSResource,
+	diff *ackcompare.Reporter,
+) error
+
+// PostUpdateHook is called after AWSResourceManager.Update with the desired
+// and latest resources, their differences and the result of the call. It
+// returns the result passed to the next hook and, eventually, to the
+// reconciler.
+type PostUpdateHook func(
+	ctx context.Context,
+	desired AWSResource,
+	latest AWSResource,
+	diff *ackcompare.Reporter,
+	updated AWSResource,
+	err error,
+) (AWSResource, error)
+
+// PreDeleteHook is called before AWSResourceManager.Delete with the resource
+// to delete. Returning an error skips the call and returns the error.
+type PreDeleteHook func(
+	ctx context.Context,
+	res AWSResource,
+) error
+
+// PostDeleteHook is called after AWSResourceManager.Delete with the resource
+// that was deleted and the error returned by the call. It returns the error
+// passed to the next hook and, eventually, to the reconciler.
+type PostDeleteHook func(
+	ctx context.Context,
+	res AWSResource,
+	err error,
+) error
+
+// ResourceManagerHooks contains the hooks called around the methods of the
+// AWSResourceManagers for a single kind of resource. Hand-written code uses
+// hooks to customize the behaviour of a generated resource manager. Hooks of
+// the same type are called in the order they were registered.
+type ResourceManagerHooks struct {
+	PreReadOne  []PreReadOneHook
+	PostReadOne []PostReadOneHook
+	PreCreate   []PreCreateHook
+	PostCreate  []PostCreateHook
+	PreUpdate   []PreUpdateHook
+	PostUpdate  []PostUpdateHook
+	PreDelete   []PreDeleteHook
+	PostDelete  []PostDeleteHook
+}
+
+// Append adds the supplied hooks after the existing hooks
+func (h *ResourceManagerHooks) Append(other *ResourceManagerHooks) {
+	h.PreReadOne = append(h.PreReadOne, other.PreReadOne...)
+	h.PostReadOne = append(h.PostReadOne, other.PostReadOne...)
+	h.PreCreate = append(h.PreCreate, other.PreCreate...)
+	h.PostCreate = append(h.PostCreate, other.PostCreate...)
+	h.PreUpdate = append(h.PreUpdate, other.PreUpdate...)
+	h.PostUpdate = append(h.PostUpdate, other.PostUpdate...)
+	h.PreDelete = append(h.PreDelete, other.PreDelete...)
+	h.PostDelete = append(h.PostDelete, other.PostDelete...)
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package types
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
+)
+
+// PolicyOperation is the kind of mutating call to the backend AWS service API
+// that a Policy is asked to allow or deny
+type PolicyOperation string
+
+const (
+	// PolicyOperationCreate is the call to AWSResourceManager.Create
+	PolicyOperationCreate PolicyOperation = "Create"
+	// PolicyOperationUpdate is the call to AWSResourceManager.Update
+	PolicyOperationUpdate PolicyOperation = "Update"
+	// PolicyOperationDelete is the call to AWSResourceManager.Delete
+	PolicyOperationDelete PolicyOperation = "Delete"
+)
+
+// PolicyRequest describes a mutating call to the backend AWS service API that
+// a Policy is asked to allow or deny
+type PolicyRequest struct {
+	// GroupKind is the kind of the resource
+	GroupKind *metav1.GroupKind
+	// Operation is the mutating call
+	Operation PolicyOperation
+	// Desired is the resource being created, updated or deleted
+	Desired AWSResource
+	// Latest is the latest observed state of the resource, or nil for a
+	// Create operation
+	Latest AWSResource
+	// Diff describes the differences between the desired and latest
+	// resources for an Update operation and is nil otherwise
+	Diff *ackcompare.Reporter
+}
+
+// Policy decides whether the service controller may make a mutating call to
+// the backend AWS service API. Policies are evaluated by the reconciler before
+// every call to the Create, Update and Delete methods of an
+// AWSResourceManager.
+type Policy interface {
+	// Evaluate returns nil if the supplied request is allowed. To deny the
+	// request, implementers should return an error wrapping
+	// ackerrors.PolicyDenied, which the reconciler records as a terminal
+	// condition on the CR. Any other error is retried.
+	Evaluate(context.Context, *PolicyRequest) error
+}
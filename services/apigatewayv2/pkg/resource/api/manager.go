@@ -17,20 +17,25 @@ package api
 
 import (
 	"context"
-	"fmt"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
 	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
-	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	"github.com/aws/aws-sdk-go/aws/session"
 
 	svcsdk "github.com/aws/aws-sdk-go/service/apigatewayv2"
 	svcsdkapi "github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+
+	svcapitypes "github.com/aws/aws-controllers-k8s/services/apigatewayv2/apis/v1alpha1"
 )
 
 // +kubebuilder:rbac:groups=apigatewayv2.services.k8s.aws,resources=apis,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apigatewayv2.services.k8s.aws,resources=apis/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // resourceManager is responsible for providing a consistent way to perform
 // CRUD operations in a backend AWS service API for Book custom resources.
@@ -76,9 +81,28 @@ func (rm *resourceManager) ReadOne(
 	if err != nil {
 		return nil, err
 	}
+	rm.setARNFromFields(observed)
 	return observed, nil
 }
 
+// ReadMany returns the currently-observed state of all the resources of this
+// kind in the backend AWS service API, in the resource manager's AWS account
+// and region
+func (rm *resourceManager) ReadMany(
+	ctx context.Context,
+) ([]acktypes.AWSResource, error) {
+	found, err := rm.sdkFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]acktypes.AWSResource, 0, len(found))
+	for _, observed := range found {
+		rm.setARNFromFields(observed)
+		res = append(res, observed)
+	}
+	return res, nil
+}
+
 // Create attempts to create the supplied AWSResource in the backend AWS
 // service API, returning an AWSResource representing the newly-created
 // resource
@@ -95,6 +119,7 @@ func (rm *resourceManager) Create(
 	if err != nil {
 		return nil, err
 	}
+	rm.setARNFromFields(created)
 	return created, nil
 }
 
@@ -143,13 +168,49 @@ func (rm *resourceManager) Delete(
 // is useful for constructing ARNs for APIs that require ARNs in their
 // GetAttributes operations but all we have (for new CRs at least) is a
 // name for the resource
+//
+// The ARN of this resource contains the values of fields other than its
+// name, so it can't be built from the name alone and the empty string is
+// returned.
 func (rm *resourceManager) ARNFromName(name string) string {
-	return fmt.Sprintf(
-		"arn:aws:apigatewayv2:%s:%s:%s",
+	return ""
+}
+
+// arnFromCR returns the AWS Resource Name of the supplied CR, built from the
+// CR's fields named by the placeholders in the resource's ARN template, or
+// the empty string if any of those fields isn't set yet
+func (rm *resourceManager) arnFromCR(ko *svcapitypes.API) string {
+	if ko.Status.APIID == nil {
+		return ""
+	}
+	return string(ackarn.FromTemplate(
+		"arn:{partition}:apigateway:{region}::/apis/{ApiId}",
 		rm.awsRegion,
 		rm.awsAccountID,
-		name,
-	)
+		"",
+		map[string]string{
+			"ApiId": *ko.Status.APIID,
+		},
+	))
+}
+
+// setARNFromFields sets the AWS Resource Name of the supplied resource from
+// the resource's fields when the backend AWS service API doesn't return it
+func (rm *resourceManager) setARNFromFields(r *resource) {
+	ko := r.ko
+	if ko.Status.ACKResourceMetadata != nil &&
+		ko.Status.ACKResourceMetadata.ARN != nil {
+		return
+	}
+	arn := rm.arnFromCR(ko)
+	if arn == "" {
+		return
+	}
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	tmpARN := ackv1alpha1.AWSResourceName(arn)
+	ko.Status.ACKResourceMetadata.ARN = &tmpARN
 }
 
 // newResourceManager returns a new struct implementing
@@ -158,11 +219,8 @@ func newResourceManager(
 	rr acktypes.AWSResourceReconciler,
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
+	sess *session.Session,
 ) (*resourceManager, error) {
-	sess, err := ackrt.NewSession(region)
-	if err != nil {
-		return nil, err
-	}
 	return &resourceManager{
 		rr:           rr,
 		awsAccountID: id,
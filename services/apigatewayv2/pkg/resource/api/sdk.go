@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.GetApiWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFoundException" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -135,13 +135,146 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.GetApisInput{}
+	for {
+		resp, err := rm.sdkapi.GetApisWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.Items {
+			ko := &svcapitypes.API{}
+			if elem.ApiEndpoint != nil {
+				ko.Status.APIEndpoint = elem.ApiEndpoint
+			}
+			if elem.ApiGatewayManaged != nil {
+				ko.Status.APIGatewayManaged = elem.ApiGatewayManaged
+			}
+			if elem.ApiId != nil {
+				ko.Status.APIID = elem.ApiId
+			}
+			if elem.ApiKeySelectionExpression != nil {
+				ko.Spec.APIKeySelectionExpression = elem.ApiKeySelectionExpression
+			}
+			if elem.CorsConfiguration != nil {
+				f4 := &svcapitypes.Cors{}
+				if elem.CorsConfiguration.AllowCredentials != nil {
+					f4.AllowCredentials = elem.CorsConfiguration.AllowCredentials
+				}
+				if elem.CorsConfiguration.AllowHeaders != nil {
+					f4f1 := []*string{}
+					for _, f4f1iter := range elem.CorsConfiguration.AllowHeaders {
+						var f4f1elem string
+						f4f1elem = *f4f1iter
+						f4f1 = append(f4f1, &f4f1elem)
+					}
+					f4.AllowHeaders = f4f1
+				}
+				if elem.CorsConfiguration.AllowMethods != nil {
+					f4f2 := []*string{}
+					for _, f4f2iter := range elem.CorsConfiguration.AllowMethods {
+						var f4f2elem string
+						f4f2elem = *f4f2iter
+						f4f2 = append(f4f2, &f4f2elem)
+					}
+					f4.AllowMethods = f4f2
+				}
+				if elem.CorsConfiguration.AllowOrigins != nil {
+					f4f3 := []*string{}
+					for _, f4f3iter := range elem.CorsConfiguration.AllowOrigins {
+						var f4f3elem string
+						f4f3elem = *f4f3iter
+						f4f3 = append(f4f3, &f4f3elem)
+					}
+					f4.AllowOrigins = f4f3
+				}
+				if elem.CorsConfiguration.ExposeHeaders != nil {
+					f4f4 := []*string{}
+					for _, f4f4iter := range elem.CorsConfiguration.ExposeHeaders {
+						var f4f4elem string
+						f4f4elem = *f4f4iter
+						f4f4 = append(f4f4, &f4f4elem)
+					}
+					f4.ExposeHeaders = f4f4
+				}
+				if elem.CorsConfiguration.MaxAge != nil {
+					f4.MaxAge = elem.CorsConfiguration.MaxAge
+				}
+				ko.Spec.CorsConfiguration = f4
+			}
+			if elem.CreatedDate != nil {
+				ko.Status.CreatedDate = &metav1.Time{*elem.CreatedDate}
+			}
+			if elem.Description != nil {
+				ko.Spec.Description = elem.Description
+			}
+			if elem.DisableExecuteApiEndpoint != nil {
+				ko.Spec.DisableExecuteAPIEndpoint = elem.DisableExecuteApiEndpoint
+			}
+			if elem.DisableSchemaValidation != nil {
+				ko.Spec.DisableSchemaValidation = elem.DisableSchemaValidation
+			}
+			if elem.ImportInfo != nil {
+				f9 := []*string{}
+				for _, f9iter := range elem.ImportInfo {
+					var f9elem string
+					f9elem = *f9iter
+					f9 = append(f9, &f9elem)
+				}
+				ko.Status.ImportInfo = f9
+			}
+			if elem.Name != nil {
+				ko.Spec.Name = elem.Name
+			}
+			if elem.ProtocolType != nil {
+				ko.Spec.ProtocolType = elem.ProtocolType
+			}
+			if elem.RouteSelectionExpression != nil {
+				ko.Spec.RouteSelectionExpression = elem.RouteSelectionExpression
+			}
+			if elem.Tags != nil {
+				f13 := map[string]*string{}
+				for f13key, f13valiter := range elem.Tags {
+					var f13val string
+					f13val = *f13valiter
+					f13[f13key] = &f13val
+				}
+				ko.Spec.Tags = f13
+			}
+			if elem.Version != nil {
+				ko.Spec.Version = elem.Version
+			}
+			if elem.Warnings != nil {
+				f15 := []*string{}
+				for _, f15iter := range elem.Warnings {
+					var f15elem string
+					f15elem = *f15iter
+					f15 = append(f15, &f15elem)
+				}
+				ko.Status.Warnings = f15
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +331,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateApiInput, error) {
 	res := &svcsdk.CreateApiInput{}
@@ -303,7 +437,7 @@ func (rm *resourceManager) sdkUpdate(
 	diffReporter *ackcompare.Reporter,
 ) (*resource, error) {
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -353,6 +487,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.UpdateApiInput, error) {
 	res := &svcsdk.UpdateApiInput{}
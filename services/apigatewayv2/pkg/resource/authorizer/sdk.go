@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.GetAuthorizerWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFoundException" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -121,7 +121,7 @@ func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +151,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateAuthorizerInput, error) {
 	res := &svcsdk.CreateAuthorizerInput{}
@@ -220,7 +221,7 @@ func (rm *resourceManager) sdkUpdate(
 	diffReporter *ackcompare.Reporter,
 ) (*resource, error) {
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +244,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.UpdateAuthorizerInput, error) {
 	res := &svcsdk.UpdateAuthorizerInput{}
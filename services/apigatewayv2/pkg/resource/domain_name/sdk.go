@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.GetDomainNameWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFoundException" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -108,13 +108,87 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.GetDomainNamesInput{}
+	for {
+		resp, err := rm.sdkapi.GetDomainNamesWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.Items {
+			ko := &svcapitypes.DomainName{}
+			if elem.ApiMappingSelectionExpression != nil {
+				ko.Status.APIMappingSelectionExpression = elem.ApiMappingSelectionExpression
+			}
+			if elem.DomainName != nil {
+				ko.Spec.DomainName = elem.DomainName
+			}
+			if elem.DomainNameConfigurations != nil {
+				f2 := []*svcapitypes.DomainNameConfiguration{}
+				for _, f2iter := range elem.DomainNameConfigurations {
+					f2elem := &svcapitypes.DomainNameConfiguration{}
+					if f2iter.ApiGatewayDomainName != nil {
+						f2elem.APIGatewayDomainName = f2iter.ApiGatewayDomainName
+					}
+					if f2iter.CertificateArn != nil {
+						f2elem.CertificateARN = f2iter.CertificateArn
+					}
+					if f2iter.CertificateName != nil {
+						f2elem.CertificateName = f2iter.CertificateName
+					}
+					if f2iter.CertificateUploadDate != nil {
+						f2elem.CertificateUploadDate = &metav1.Time{*f2iter.CertificateUploadDate}
+					}
+					if f2iter.DomainNameStatus != nil {
+						f2elem.DomainNameStatus = f2iter.DomainNameStatus
+					}
+					if f2iter.DomainNameStatusMessage != nil {
+						f2elem.DomainNameStatusMessage = f2iter.DomainNameStatusMessage
+					}
+					if f2iter.EndpointType != nil {
+						f2elem.EndpointType = f2iter.EndpointType
+					}
+					if f2iter.HostedZoneId != nil {
+						f2elem.HostedZoneID = f2iter.HostedZoneId
+					}
+					if f2iter.SecurityPolicy != nil {
+						f2elem.SecurityPolicy = f2iter.SecurityPolicy
+					}
+					f2 = append(f2, f2elem)
+				}
+				ko.Spec.DomainNameConfigurations = f2
+			}
+			if elem.Tags != nil {
+				f4 := map[string]*string{}
+				for f4key, f4valiter := range elem.Tags {
+					var f4val string
+					f4val = *f4valiter
+					f4[f4key] = &f4val
+				}
+				ko.Spec.Tags = f4
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +218,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateDomainNameInput, error) {
 	res := &svcsdk.CreateDomainNameInput{}
@@ -218,7 +293,7 @@ func (rm *resourceManager) sdkUpdate(
 	diffReporter *ackcompare.Reporter,
 ) (*resource, error) {
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +316,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.UpdateDomainNameInput, error) {
 	res := &svcsdk.UpdateDomainNameInput{}
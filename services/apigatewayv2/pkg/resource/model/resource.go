@@ -67,3 +67,37 @@ func (r *resource) RuntimeMetaObject() acktypes.RuntimeMetaObject {
 func (r *resource) Conditions() []*ackv1alpha1.Condition {
 	return r.ko.Status.Conditions
 }
+
+// SetConditions replaces the ACK Conditions collection for the AWSResource
+func (r *resource) SetConditions(conditions []*ackv1alpha1.Condition) {
+	r.ko.Status.Conditions = conditions
+}
+
+// ResourceName returns the name of the backend AWS service API resource, or
+// the empty string if the name isn't known
+func (r *resource) ResourceName() string {
+	if r.ko.Spec.Name == nil {
+		return ""
+	}
+	return *r.ko.Spec.Name
+}
+
+// SetResourceName sets the name of the backend AWS service API resource
+func (r *resource) SetResourceName(name string) {
+	r.ko.Spec.Name = &name
+}
+
+// ParentKind returns the kind of the resource whose backend AWS service API
+// resource contains the resource's
+func (r *resource) ParentKind() string {
+	return "API"
+}
+
+// ParentIdentifier returns the identifier of the parent's backend AWS service
+// API resource, or the empty string if it isn't known
+func (r *resource) ParentIdentifier() string {
+	if r.ko.Spec.APIID == nil {
+		return ""
+	}
+	return *r.ko.Spec.APIID
+}
@@ -16,6 +16,7 @@
 package resource
 
 import (
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
 	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 )
@@ -24,6 +25,13 @@ var (
 	reg = ackrt.NewRegistry()
 )
 
+func init() {
+	ackerr.RegisterErrorCodes(
+		"apigatewayv2.services.k8s.aws", ackerr.ClassNotFound,
+		"NotFoundException",
+	)
+}
+
 // GetManagerFactories returns a slice of resource manager factories that are
 // registered with this package
 func GetManagerFactories() []acktypes.AWSResourceManagerFactory {
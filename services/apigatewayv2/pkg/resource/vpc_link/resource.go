@@ -67,3 +67,44 @@ func (r *resource) RuntimeMetaObject() acktypes.RuntimeMetaObject {
 func (r *resource) Conditions() []*ackv1alpha1.Condition {
 	return r.ko.Status.Conditions
 }
+
+// SetConditions replaces the ACK Conditions collection for the AWSResource
+func (r *resource) SetConditions(conditions []*ackv1alpha1.Condition) {
+	r.ko.Status.Conditions = conditions
+}
+
+// ResourceName returns the name of the backend AWS service API resource, or
+// the empty string if the name isn't known
+func (r *resource) ResourceName() string {
+	if r.ko.Spec.Name == nil {
+		return ""
+	}
+	return *r.ko.Spec.Name
+}
+
+// SetResourceName sets the name of the backend AWS service API resource
+func (r *resource) SetResourceName(name string) {
+	r.ko.Spec.Name = &name
+}
+
+// GetTags returns the tags of the AWSResource as a map of tag keys to tag
+// values
+func (r *resource) GetTags() map[string]string {
+	tags := map[string]string{}
+	for k, v := range r.ko.Spec.Tags {
+		if v != nil {
+			tags[k] = *v
+		}
+	}
+	return tags
+}
+
+// SetTags replaces the tags of the AWSResource with the supplied map of tag
+// keys to tag values
+func (r *resource) SetTags(tags map[string]string) {
+	r.ko.Spec.Tags = make(map[string]*string, len(tags))
+	for k, v := range tags {
+		v := v
+		r.ko.Spec.Tags[k] = &v
+	}
+}
@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.GetVpcLinkWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFoundException" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -120,13 +120,82 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.GetVpcLinksInput{}
+	for {
+		resp, err := rm.sdkapi.GetVpcLinksWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.Items {
+			ko := &svcapitypes.VPCLink{}
+			if elem.CreatedDate != nil {
+				ko.Status.CreatedDate = &metav1.Time{*elem.CreatedDate}
+			}
+			if elem.Name != nil {
+				ko.Spec.Name = elem.Name
+			}
+			if elem.SecurityGroupIds != nil {
+				f2 := []*string{}
+				for _, f2iter := range elem.SecurityGroupIds {
+					var f2elem string
+					f2elem = *f2iter
+					f2 = append(f2, &f2elem)
+				}
+				ko.Spec.SecurityGroupIDs = f2
+			}
+			if elem.SubnetIds != nil {
+				f3 := []*string{}
+				for _, f3iter := range elem.SubnetIds {
+					var f3elem string
+					f3elem = *f3iter
+					f3 = append(f3, &f3elem)
+				}
+				ko.Spec.SubnetIDs = f3
+			}
+			if elem.Tags != nil {
+				f4 := map[string]*string{}
+				for f4key, f4valiter := range elem.Tags {
+					var f4val string
+					f4val = *f4valiter
+					f4[f4key] = &f4val
+				}
+				ko.Spec.Tags = f4
+			}
+			if elem.VpcLinkId != nil {
+				ko.Status.VPCLinkID = elem.VpcLinkId
+			}
+			if elem.VpcLinkStatus != nil {
+				ko.Status.VPCLinkStatus = elem.VpcLinkStatus
+			}
+			if elem.VpcLinkStatusMessage != nil {
+				ko.Status.VPCLinkStatusMessage = elem.VpcLinkStatusMessage
+			}
+			if elem.VpcLinkVersion != nil {
+				ko.Status.VPCLinkVersion = elem.VpcLinkVersion
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +237,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateVpcLinkInput, error) {
 	res := &svcsdk.CreateVpcLinkInput{}
@@ -215,7 +285,7 @@ func (rm *resourceManager) sdkUpdate(
 	diffReporter *ackcompare.Reporter,
 ) (*resource, error) {
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +320,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.UpdateVpcLinkInput, error) {
 	res := &svcsdk.UpdateVpcLinkInput{}
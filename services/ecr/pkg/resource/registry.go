@@ -16,6 +16,7 @@
 package resource
 
 import (
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
 	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 )
@@ -24,6 +25,20 @@ var (
 	reg = ackrt.NewRegistry()
 )
 
+func init() {
+	ackerr.RegisterErrorCodes(
+		"ecr.services.k8s.aws", ackerr.ClassConflict,
+		"RepositoryAlreadyExistsException",
+		"RepositoryNotEmptyException",
+	)
+	ackerr.RegisterErrorCodes(
+		"ecr.services.k8s.aws", ackerr.ClassNotFound,
+		"RepositoryNotFoundException",
+		"RepositoryPolicyNotFoundException",
+		"LifecyclePolicyNotFoundException",
+	)
+}
+
 // GetManagerFactories returns a slice of resource manager factories that are
 // registered with this package
 func GetManagerFactories() []acktypes.AWSResourceManagerFactory {
@@ -16,6 +16,8 @@
 package repository
 
 import (
+	"sort"
+
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,3 +69,54 @@ func (r *resource) RuntimeMetaObject() acktypes.RuntimeMetaObject {
 func (r *resource) Conditions() []*ackv1alpha1.Condition {
 	return r.ko.Status.Conditions
 }
+
+// SetConditions replaces the ACK Conditions collection for the AWSResource
+func (r *resource) SetConditions(conditions []*ackv1alpha1.Condition) {
+	r.ko.Status.Conditions = conditions
+}
+
+// ResourceName returns the name of the backend AWS service API resource, or
+// the empty string if the name isn't known
+func (r *resource) ResourceName() string {
+	if r.ko.Spec.RepositoryName == nil {
+		return ""
+	}
+	return *r.ko.Spec.RepositoryName
+}
+
+// SetResourceName sets the name of the backend AWS service API resource
+func (r *resource) SetResourceName(name string) {
+	r.ko.Spec.RepositoryName = &name
+}
+
+// GetTags returns the tags of the AWSResource as a map of tag keys to tag
+// values
+func (r *resource) GetTags() map[string]string {
+	tags := map[string]string{}
+	for _, tag := range r.ko.Spec.Tags {
+		if tag != nil && tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+	return tags
+}
+
+// SetTags replaces the tags of the AWSResource with the supplied map of tag
+// keys to tag values
+func (r *resource) SetTags(tags map[string]string) {
+	// Tags are sorted by key so that resources with the same tags are equal
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	r.ko.Spec.Tags = make([]*svcapitypes.Tag, 0, len(keys))
+	for _, k := range keys {
+		k := k
+		v := tags[k]
+		r.ko.Spec.Tags = append(
+			r.ko.Spec.Tags,
+			&svcapitypes.Tag{Key: &k, Value: &v},
+		)
+	}
+}
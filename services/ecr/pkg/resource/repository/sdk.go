@@ -50,7 +50,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.DescribeRepositoriesWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "RepositoryNotFoundException" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -133,13 +133,76 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.DescribeRepositoriesInput{}
+	for {
+		resp, err := rm.sdkapi.DescribeRepositoriesWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.Repositories {
+			ko := &svcapitypes.Repository{}
+			if elem.CreatedAt != nil {
+				ko.Status.CreatedAt = &metav1.Time{*elem.CreatedAt}
+			}
+			if elem.EncryptionConfiguration != nil {
+				f1 := &svcapitypes.EncryptionConfiguration{}
+				if elem.EncryptionConfiguration.EncryptionType != nil {
+					f1.EncryptionType = elem.EncryptionConfiguration.EncryptionType
+				}
+				if elem.EncryptionConfiguration.KmsKey != nil {
+					f1.KMSKey = elem.EncryptionConfiguration.KmsKey
+				}
+				ko.Spec.EncryptionConfiguration = f1
+			}
+			if elem.ImageScanningConfiguration != nil {
+				f2 := &svcapitypes.ImageScanningConfiguration{}
+				if elem.ImageScanningConfiguration.ScanOnPush != nil {
+					f2.ScanOnPush = elem.ImageScanningConfiguration.ScanOnPush
+				}
+				ko.Spec.ImageScanningConfiguration = f2
+			}
+			if elem.ImageTagMutability != nil {
+				ko.Spec.ImageTagMutability = elem.ImageTagMutability
+			}
+			if elem.RegistryId != nil {
+				ko.Status.RegistryID = elem.RegistryId
+			}
+			if elem.RepositoryArn != nil {
+				if ko.Status.ACKResourceMetadata == nil {
+					ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+				}
+				tmpARN := ackv1alpha1.AWSResourceName(*elem.RepositoryArn)
+				ko.Status.ACKResourceMetadata.ARN = &tmpARN
+			}
+			if elem.RepositoryName != nil {
+				ko.Spec.RepositoryName = elem.RepositoryName
+			}
+			if elem.RepositoryUri != nil {
+				ko.Status.RepositoryURI = elem.RepositoryUri
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +245,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateRepositoryInput, error) {
 	res := &svcsdk.CreateRepositoryInput{}
@@ -268,3 +332,60 @@ func (rm *resourceManager) newDeleteRequestPayload(
 
 	return res, nil
 }
+
+// sdkFindTags returns the tags of the supplied resource in the backend AWS
+// service API
+func (rm *resourceManager) sdkFindTags(
+	ctx context.Context,
+	r *resource,
+) (map[string]string, error) {
+	input := &svcsdk.ListTagsForResourceInput{}
+	input.SetResourceArn(string(*r.Identifiers().ARN()))
+	resp, respErr := rm.sdkapi.ListTagsForResourceWithContext(ctx, input)
+	if respErr != nil {
+		return nil, respErr
+	}
+	tags := map[string]string{}
+	for _, tag := range resp.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// sdkUpdateTags adds, updates and removes tags of the supplied latest
+// resource in the backend AWS service API so that they match the tags of the
+// supplied desired resource
+func (rm *resourceManager) sdkUpdateTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	id := string(*latest.Identifiers().ARN())
+	toAdd, toRemove := ackcompare.DiffTags(desired.GetTags(), latest.GetTags())
+	if len(toRemove) > 0 {
+		input := &svcsdk.UntagResourceInput{}
+		input.SetResourceArn(id)
+		input.SetTagKeys(aws.StringSlice(toRemove))
+		_, respErr := rm.sdkapi.UntagResourceWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	if len(toAdd) > 0 {
+		input := &svcsdk.TagResourceInput{}
+		input.SetResourceArn(id)
+		tags := make([]*svcsdk.Tag, 0, len(toAdd))
+		for k, v := range toAdd {
+			tag := &svcsdk.Tag{}
+			tag.SetKey(k)
+			tag.SetValue(v)
+			tags = append(tags, tag)
+		}
+		input.SetTags(tags)
+		_, respErr := rm.sdkapi.TagResourceWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	return nil
+}
@@ -17,11 +17,10 @@ package cache_subnet_group
 
 import (
 	"context"
-	"fmt"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
 	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
-	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	"github.com/aws/aws-sdk-go/aws/session"
 
@@ -31,6 +30,10 @@ import (
 
 // +kubebuilder:rbac:groups=elasticache.services.k8s.aws,resources=cachesubnetgroups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=elasticache.services.k8s.aws,resources=cachesubnetgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // resourceManager is responsible for providing a consistent way to perform
 // CRUD operations in a backend AWS service API for Book custom resources.
@@ -79,6 +82,23 @@ func (rm *resourceManager) ReadOne(
 	return observed, nil
 }
 
+// ReadMany returns the currently-observed state of all the resources of this
+// kind in the backend AWS service API, in the resource manager's AWS account
+// and region
+func (rm *resourceManager) ReadMany(
+	ctx context.Context,
+) ([]acktypes.AWSResource, error) {
+	found, err := rm.sdkFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]acktypes.AWSResource, 0, len(found))
+	for _, observed := range found {
+		res = append(res, observed)
+	}
+	return res, nil
+}
+
 // Create attempts to create the supplied AWSResource in the backend AWS
 // service API, returning an AWSResource representing the newly-created
 // resource
@@ -144,12 +164,13 @@ func (rm *resourceManager) Delete(
 // GetAttributes operations but all we have (for new CRs at least) is a
 // name for the resource
 func (rm *resourceManager) ARNFromName(name string) string {
-	return fmt.Sprintf(
-		"arn:aws:elasticache:%s:%s:%s",
+	return string(ackarn.FromTemplate(
+		"arn:{partition}:elasticache:{region}:{account}:subnetgroup:{name}",
 		rm.awsRegion,
 		rm.awsAccountID,
 		name,
-	)
+		nil,
+	))
 }
 
 // newResourceManager returns a new struct implementing
@@ -158,11 +179,8 @@ func newResourceManager(
 	rr acktypes.AWSResourceReconciler,
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
+	sess *session.Session,
 ) (*resourceManager, error) {
-	sess, err := ackrt.NewSession(region)
-	if err != nil {
-		return nil, err
-	}
 	return &resourceManager{
 		rr:           rr,
 		awsAccountID: id,
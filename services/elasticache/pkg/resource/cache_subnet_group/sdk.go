@@ -50,7 +50,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.DescribeCacheSubnetGroupsWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "CacheSubnetGroupNotFoundFault" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -123,13 +123,71 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.DescribeCacheSubnetGroupsInput{}
+	for {
+		resp, err := rm.sdkapi.DescribeCacheSubnetGroupsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.CacheSubnetGroups {
+			ko := &svcapitypes.CacheSubnetGroup{}
+			if elem.ARN != nil {
+				if ko.Status.ACKResourceMetadata == nil {
+					ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+				}
+				tmpARN := ackv1alpha1.AWSResourceName(*elem.ARN)
+				ko.Status.ACKResourceMetadata.ARN = &tmpARN
+			}
+			if elem.CacheSubnetGroupDescription != nil {
+				ko.Spec.CacheSubnetGroupDescription = elem.CacheSubnetGroupDescription
+			}
+			if elem.CacheSubnetGroupName != nil {
+				ko.Spec.CacheSubnetGroupName = elem.CacheSubnetGroupName
+			}
+			if elem.Subnets != nil {
+				f3 := []*svcapitypes.Subnet{}
+				for _, f3iter := range elem.Subnets {
+					f3elem := &svcapitypes.Subnet{}
+					if f3iter.SubnetAvailabilityZone != nil {
+						f3elemf0 := &svcapitypes.AvailabilityZone{}
+						if f3iter.SubnetAvailabilityZone.Name != nil {
+							f3elemf0.Name = f3iter.SubnetAvailabilityZone.Name
+						}
+						f3elem.SubnetAvailabilityZone = f3elemf0
+					}
+					if f3iter.SubnetIdentifier != nil {
+						f3elem.SubnetIdentifier = f3iter.SubnetIdentifier
+					}
+					f3 = append(f3, f3elem)
+				}
+				ko.Status.Subnets = f3
+			}
+			if elem.VpcId != nil {
+				ko.Status.VPCID = elem.VpcId
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.Marker == nil || *resp.Marker == "" {
+			break
+		}
+		input.Marker = resp.Marker
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +242,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateCacheSubnetGroupInput, error) {
 	res := &svcsdk.CreateCacheSubnetGroupInput{}
@@ -216,7 +275,7 @@ func (rm *resourceManager) sdkUpdate(
 	diffReporter *ackcompare.Reporter,
 ) (*resource, error) {
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +323,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.ModifyCacheSubnetGroupInput, error) {
 	res := &svcsdk.ModifyCacheSubnetGroupInput{}
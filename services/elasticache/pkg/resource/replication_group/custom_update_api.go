@@ -48,6 +48,11 @@ func (rm *resourceManager) CustomModifyReplicationGroup(
 		return rm.updateShardConfiguration(ctx, desired, latest)
 	}
 
+	// ModifyReplicationGroup rejects an auth token without an update strategy
+	if desired.ko.Spec.AuthToken != nil {
+		return rm.modifyReplicationGroup(ctx, desired, diffReporter.DifferentAt("Spec.AuthToken"))
+	}
+
 	// no updates
 	return nil, nil
 }
@@ -227,6 +232,43 @@ func (rm *resourceManager) updateShardConfiguration(
 	return provideUpdatedResource(desired, resp.ReplicationGroup)
 }
 
+func (rm *resourceManager) modifyReplicationGroup(
+	ctx context.Context,
+	desired *resource,
+	rotateAuthToken bool,
+) (*resource, error) {
+	input, err := rm.newModifyReplicationGroupRequestPayload(ctx, desired, rotateAuthToken)
+	if err != nil {
+		return nil, err
+	}
+	resp, respErr := rm.sdkapi.ModifyReplicationGroupWithContext(ctx, input)
+	if respErr != nil {
+		return nil, respErr
+	}
+	return provideUpdatedResource(desired, resp.ReplicationGroup)
+}
+
+// newModifyReplicationGroupRequestPayload returns an SDK-specific struct for
+// the HTTP request payload of the Update API call for the resource. The auth
+// token is only sent, with the ROTATE update strategy, when it changed;
+// otherwise the replication group's current auth token is left alone.
+func (rm *resourceManager) newModifyReplicationGroupRequestPayload(
+	ctx context.Context,
+	desired *resource,
+	rotateAuthToken bool,
+) (*svcsdk.ModifyReplicationGroupInput, error) {
+	res, err := rm.newUpdateRequestPayload(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	if rotateAuthToken {
+		res.SetAuthTokenUpdateStrategy(svcsdk.AuthTokenUpdateStrategyTypeRotate)
+	} else {
+		res.AuthToken = nil
+	}
+	return res, nil
+}
+
 // newIncreaseReplicaCountRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newIncreaseReplicaCountRequestPayload(
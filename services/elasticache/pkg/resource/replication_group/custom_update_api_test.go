@@ -711,3 +711,33 @@ func TestNewUpdateShardConfigurationRequestPayload(t *testing.T) {
 		assert.Nil(err)
 	})
 }
+
+func TestNewModifyReplicationGroupRequestPayload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	// setup
+	rm := provideResourceManager()
+	replicationGroupID := "test-rg"
+	authToken := "0123456789abcdef"
+	desired := provideResource()
+	desired.ko.Spec.ReplicationGroupID = &replicationGroupID
+	desired.ko.Spec.AuthToken = &authToken
+	// Tests
+	t.Run("AuthTokenUnchanged", func(t *testing.T) {
+		payload, err := rm.newModifyReplicationGroupRequestPayload(context.TODO(), desired, false)
+		require.Nil(err)
+		require.NotNil(payload)
+		assert.Equal(replicationGroupID, *payload.ReplicationGroupId)
+		assert.Nil(payload.AuthToken)
+		assert.Nil(payload.AuthTokenUpdateStrategy)
+	})
+	t.Run("AuthTokenChanged", func(t *testing.T) {
+		payload, err := rm.newModifyReplicationGroupRequestPayload(context.TODO(), desired, true)
+		require.Nil(err)
+		require.NotNil(payload)
+		require.NotNil(payload.AuthToken)
+		assert.Equal(authToken, *payload.AuthToken)
+		require.NotNil(payload.AuthTokenUpdateStrategy)
+		assert.Equal(svcsdk.AuthTokenUpdateStrategyTypeRotate, *payload.AuthTokenUpdateStrategy)
+	})
+}
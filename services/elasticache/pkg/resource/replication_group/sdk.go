@@ -50,7 +50,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.DescribeReplicationGroupsWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "ReplicationGroupNotFoundFault" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -264,13 +264,209 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.DescribeReplicationGroupsInput{}
+	for {
+		resp, err := rm.sdkapi.DescribeReplicationGroupsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.ReplicationGroups {
+			ko := &svcapitypes.ReplicationGroup{}
+			if elem.ARN != nil {
+				if ko.Status.ACKResourceMetadata == nil {
+					ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+				}
+				tmpARN := ackv1alpha1.AWSResourceName(*elem.ARN)
+				ko.Status.ACKResourceMetadata.ARN = &tmpARN
+			}
+			if elem.AtRestEncryptionEnabled != nil {
+				ko.Spec.AtRestEncryptionEnabled = elem.AtRestEncryptionEnabled
+			}
+			if elem.AuthTokenEnabled != nil {
+				ko.Status.AuthTokenEnabled = elem.AuthTokenEnabled
+			}
+			if elem.AuthTokenLastModifiedDate != nil {
+				ko.Status.AuthTokenLastModifiedDate = &metav1.Time{*elem.AuthTokenLastModifiedDate}
+			}
+			if elem.AutomaticFailover != nil {
+				ko.Status.AutomaticFailover = elem.AutomaticFailover
+			}
+			if elem.CacheNodeType != nil {
+				ko.Spec.CacheNodeType = elem.CacheNodeType
+			}
+			if elem.ClusterEnabled != nil {
+				ko.Status.ClusterEnabled = elem.ClusterEnabled
+			}
+			if elem.ConfigurationEndpoint != nil {
+				f7 := &svcapitypes.Endpoint{}
+				if elem.ConfigurationEndpoint.Address != nil {
+					f7.Address = elem.ConfigurationEndpoint.Address
+				}
+				if elem.ConfigurationEndpoint.Port != nil {
+					f7.Port = elem.ConfigurationEndpoint.Port
+				}
+				ko.Status.ConfigurationEndpoint = f7
+			}
+			if elem.Description != nil {
+				ko.Status.Description = elem.Description
+			}
+			if elem.GlobalReplicationGroupInfo != nil {
+				f9 := &svcapitypes.GlobalReplicationGroupInfo{}
+				if elem.GlobalReplicationGroupInfo.GlobalReplicationGroupId != nil {
+					f9.GlobalReplicationGroupID = elem.GlobalReplicationGroupInfo.GlobalReplicationGroupId
+				}
+				if elem.GlobalReplicationGroupInfo.GlobalReplicationGroupMemberRole != nil {
+					f9.GlobalReplicationGroupMemberRole = elem.GlobalReplicationGroupInfo.GlobalReplicationGroupMemberRole
+				}
+				ko.Status.GlobalReplicationGroupInfo = f9
+			}
+			if elem.KmsKeyId != nil {
+				ko.Spec.KMSKeyID = elem.KmsKeyId
+			}
+			if elem.MemberClusters != nil {
+				f11 := []*string{}
+				for _, f11iter := range elem.MemberClusters {
+					var f11elem string
+					f11elem = *f11iter
+					f11 = append(f11, &f11elem)
+				}
+				ko.Status.MemberClusters = f11
+			}
+			if elem.MultiAZ != nil {
+				ko.Status.MultiAZ = elem.MultiAZ
+			}
+			if elem.NodeGroups != nil {
+				f13 := []*svcapitypes.NodeGroup{}
+				for _, f13iter := range elem.NodeGroups {
+					f13elem := &svcapitypes.NodeGroup{}
+					if f13iter.NodeGroupId != nil {
+						f13elem.NodeGroupID = f13iter.NodeGroupId
+					}
+					if f13iter.NodeGroupMembers != nil {
+						f13elemf1 := []*svcapitypes.NodeGroupMember{}
+						for _, f13elemf1iter := range f13iter.NodeGroupMembers {
+							f13elemf1elem := &svcapitypes.NodeGroupMember{}
+							if f13elemf1iter.CacheClusterId != nil {
+								f13elemf1elem.CacheClusterID = f13elemf1iter.CacheClusterId
+							}
+							if f13elemf1iter.CacheNodeId != nil {
+								f13elemf1elem.CacheNodeID = f13elemf1iter.CacheNodeId
+							}
+							if f13elemf1iter.CurrentRole != nil {
+								f13elemf1elem.CurrentRole = f13elemf1iter.CurrentRole
+							}
+							if f13elemf1iter.PreferredAvailabilityZone != nil {
+								f13elemf1elem.PreferredAvailabilityZone = f13elemf1iter.PreferredAvailabilityZone
+							}
+							if f13elemf1iter.ReadEndpoint != nil {
+								f13elemf1elemf4 := &svcapitypes.Endpoint{}
+								if f13elemf1iter.ReadEndpoint.Address != nil {
+									f13elemf1elemf4.Address = f13elemf1iter.ReadEndpoint.Address
+								}
+								if f13elemf1iter.ReadEndpoint.Port != nil {
+									f13elemf1elemf4.Port = f13elemf1iter.ReadEndpoint.Port
+								}
+								f13elemf1elem.ReadEndpoint = f13elemf1elemf4
+							}
+							f13elemf1 = append(f13elemf1, f13elemf1elem)
+						}
+						f13elem.NodeGroupMembers = f13elemf1
+					}
+					if f13iter.PrimaryEndpoint != nil {
+						f13elemf2 := &svcapitypes.Endpoint{}
+						if f13iter.PrimaryEndpoint.Address != nil {
+							f13elemf2.Address = f13iter.PrimaryEndpoint.Address
+						}
+						if f13iter.PrimaryEndpoint.Port != nil {
+							f13elemf2.Port = f13iter.PrimaryEndpoint.Port
+						}
+						f13elem.PrimaryEndpoint = f13elemf2
+					}
+					if f13iter.ReaderEndpoint != nil {
+						f13elemf3 := &svcapitypes.Endpoint{}
+						if f13iter.ReaderEndpoint.Address != nil {
+							f13elemf3.Address = f13iter.ReaderEndpoint.Address
+						}
+						if f13iter.ReaderEndpoint.Port != nil {
+							f13elemf3.Port = f13iter.ReaderEndpoint.Port
+						}
+						f13elem.ReaderEndpoint = f13elemf3
+					}
+					if f13iter.Slots != nil {
+						f13elem.Slots = f13iter.Slots
+					}
+					if f13iter.Status != nil {
+						f13elem.Status = f13iter.Status
+					}
+					f13 = append(f13, f13elem)
+				}
+				ko.Status.NodeGroups = f13
+			}
+			if elem.PendingModifiedValues != nil {
+				f14 := &svcapitypes.ReplicationGroupPendingModifiedValues{}
+				if elem.PendingModifiedValues.AuthTokenStatus != nil {
+					f14.AuthTokenStatus = elem.PendingModifiedValues.AuthTokenStatus
+				}
+				if elem.PendingModifiedValues.AutomaticFailoverStatus != nil {
+					f14.AutomaticFailoverStatus = elem.PendingModifiedValues.AutomaticFailoverStatus
+				}
+				if elem.PendingModifiedValues.PrimaryClusterId != nil {
+					f14.PrimaryClusterID = elem.PendingModifiedValues.PrimaryClusterId
+				}
+				if elem.PendingModifiedValues.Resharding != nil {
+					f14f3 := &svcapitypes.ReshardingStatus{}
+					if elem.PendingModifiedValues.Resharding.SlotMigration != nil {
+						f14f3f0 := &svcapitypes.SlotMigration{}
+						if elem.PendingModifiedValues.Resharding.SlotMigration.ProgressPercentage != nil {
+							f14f3f0.ProgressPercentage = elem.PendingModifiedValues.Resharding.SlotMigration.ProgressPercentage
+						}
+						f14f3.SlotMigration = f14f3f0
+					}
+					f14.Resharding = f14f3
+				}
+				ko.Status.PendingModifiedValues = f14
+			}
+			if elem.ReplicationGroupId != nil {
+				ko.Spec.ReplicationGroupID = elem.ReplicationGroupId
+			}
+			if elem.SnapshotRetentionLimit != nil {
+				ko.Spec.SnapshotRetentionLimit = elem.SnapshotRetentionLimit
+			}
+			if elem.SnapshotWindow != nil {
+				ko.Spec.SnapshotWindow = elem.SnapshotWindow
+			}
+			if elem.SnapshottingClusterId != nil {
+				ko.Status.SnapshottingClusterID = elem.SnapshottingClusterId
+			}
+			if elem.Status != nil {
+				ko.Status.Status = elem.Status
+			}
+			if elem.TransitEncryptionEnabled != nil {
+				ko.Spec.TransitEncryptionEnabled = elem.TransitEncryptionEnabled
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.Marker == nil || *resp.Marker == "" {
+			break
+		}
+		input.Marker = resp.Marker
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -451,6 +647,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateReplicationGroupInput, error) {
 	res := &svcsdk.CreateReplicationGroupInput{}
@@ -627,7 +824,7 @@ func (rm *resourceManager) sdkUpdate(
 		return customResp, customRespErr
 	}
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -801,6 +998,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.ModifyReplicationGroupInput, error) {
 	res := &svcsdk.ModifyReplicationGroupInput{}
@@ -31,6 +31,7 @@ type BucketSpec struct {
 	GrantWriteACP              *string                    `json:"grantWriteACP,omitempty"`
 	Name                       *string                    `json:"name,omitempty"`
 	ObjectLockEnabledForBucket *bool                      `json:"objectLockEnabledForBucket,omitempty"`
+	Tags                       []*Tag                     `json:"tags,omitempty"`
 }
 
 // BucketStatus defines the observed state of Bucket
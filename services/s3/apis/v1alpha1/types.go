@@ -23,6 +23,14 @@ type AccessControlPolicy struct {
 	Owner *Owner `json:"owner,omitempty"`
 }
 
+type AnalyticsAndOperator struct {
+	Tags []*Tag `json:"tags,omitempty"`
+}
+
+type AnalyticsFilter struct {
+	Tag *Tag `json:"tag,omitempty"`
+}
+
 type AnalyticsS3BucketDestination struct {
 	Bucket          *string `json:"bucket,omitempty"`
 	BucketAccountID *string `json:"bucketAccountID,omitempty"`
@@ -38,7 +46,12 @@ type CreateBucketConfiguration struct {
 }
 
 type DeleteMarkerEntry struct {
-	Owner *Owner `json:"owner,omitempty"`
+	Key   *string `json:"key,omitempty"`
+	Owner *Owner  `json:"owner,omitempty"`
+}
+
+type DeletedObject struct {
+	Key *string `json:"key,omitempty"`
 }
 
 type Destination struct {
@@ -46,6 +59,14 @@ type Destination struct {
 	Bucket  *string `json:"bucket,omitempty"`
 }
 
+type Error struct {
+	Key *string `json:"key,omitempty"`
+}
+
+type ErrorDocument struct {
+	Key *string `json:"key,omitempty"`
+}
+
 type Grantee struct {
 	DisplayName *string `json:"displayName,omitempty"`
 	ID          *string `json:"id,omitempty"`
@@ -65,20 +86,43 @@ type LifecycleRule struct {
 	ID *string `json:"id,omitempty"`
 }
 
+type LifecycleRuleAndOperator struct {
+	Tags []*Tag `json:"tags,omitempty"`
+}
+
+type LifecycleRuleFilter struct {
+	Tag *Tag `json:"tag,omitempty"`
+}
+
 type Location struct {
 	BucketName *string `json:"bucketName,omitempty"`
 }
 
+type MetricsAndOperator struct {
+	Tags []*Tag `json:"tags,omitempty"`
+}
+
+type MetricsFilter struct {
+	Tag *Tag `json:"tag,omitempty"`
+}
+
 type MultipartUpload struct {
-	Owner *Owner `json:"owner,omitempty"`
+	Key   *string `json:"key,omitempty"`
+	Owner *Owner  `json:"owner,omitempty"`
 }
 
 type Object struct {
-	Owner *Owner `json:"owner,omitempty"`
+	Key   *string `json:"key,omitempty"`
+	Owner *Owner  `json:"owner,omitempty"`
+}
+
+type ObjectIdentifier struct {
+	Key *string `json:"key,omitempty"`
 }
 
 type ObjectVersion struct {
-	Owner *Owner `json:"owner,omitempty"`
+	Key   *string `json:"key,omitempty"`
+	Owner *Owner  `json:"owner,omitempty"`
 }
 
 type OutputLocation struct {
@@ -94,6 +138,23 @@ type ReplicationRule struct {
 	ID *string `json:"id,omitempty"`
 }
 
+type ReplicationRuleAndOperator struct {
+	Tags []*Tag `json:"tags,omitempty"`
+}
+
+type ReplicationRuleFilter struct {
+	Tag *Tag `json:"tag,omitempty"`
+}
+
 type Rule struct {
 	ID *string `json:"id,omitempty"`
 }
+
+type Tag struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type Tagging struct {
+	TagSet []*Tag `json:"tagSet,omitempty"`
+}
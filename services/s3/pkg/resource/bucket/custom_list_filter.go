@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package bucket
+
+import (
+	"context"
+
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/s3"
+)
+
+// CustomBucketInRegion returns true if the supplied bucket, returned by
+// ListBuckets, is in the resource manager's region. ListBuckets returns the
+// buckets in every region, so the location of each bucket is looked up.
+func (rm *resourceManager) CustomBucketInRegion(
+	ctx context.Context,
+	r *resource,
+) (bool, error) {
+	input := &svcsdk.GetBucketLocationInput{}
+	input.SetBucket(*r.ko.Spec.Name)
+	resp, err := rm.sdkapi.GetBucketLocationWithContext(ctx, input)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok &&
+			awsErr.Code() == svcsdk.ErrCodeNoSuchBucket {
+			// The bucket was deleted after it was listed
+			return false, nil
+		}
+		return false, err
+	}
+	return bucketRegion(resp.LocationConstraint) == string(rm.awsRegion), nil
+}
+
+// bucketRegion returns the region of a bucket from the supplied location
+// constraint returned by GetBucketLocation. Buckets in us-east-1 have no
+// location constraint and buckets created with the legacy EU constraint are
+// in eu-west-1.
+func bucketRegion(locationConstraint *string) string {
+	switch constraint := aws.StringValue(locationConstraint); constraint {
+	case "":
+		return "us-east-1"
+	case svcsdk.BucketLocationConstraintEu:
+		return "eu-west-1"
+	default:
+		return constraint
+	}
+}
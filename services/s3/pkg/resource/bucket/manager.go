@@ -17,11 +17,10 @@ package bucket
 
 import (
 	"context"
-	"fmt"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
 	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
-	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	"github.com/aws/aws-sdk-go/aws/session"
 
@@ -31,6 +30,10 @@ import (
 
 // +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=buckets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=s3.services.k8s.aws,resources=buckets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // resourceManager is responsible for providing a consistent way to perform
 // CRUD operations in a backend AWS service API for Book custom resources.
@@ -76,9 +79,42 @@ func (rm *resourceManager) ReadOne(
 	if err != nil {
 		return nil, err
 	}
+	// The tags of the resource aren't returned by the read operation
+	if observed.ko.Spec.Name != nil {
+		tags, err := rm.sdkFindTags(ctx, observed)
+		if err != nil {
+			return nil, err
+		}
+		observed.SetTags(tags)
+	}
 	return observed, nil
 }
 
+// ReadMany returns the currently-observed state of all the resources of this
+// kind in the backend AWS service API, in the resource manager's AWS account
+// and region
+func (rm *resourceManager) ReadMany(
+	ctx context.Context,
+) ([]acktypes.AWSResource, error) {
+	found, err := rm.sdkFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]acktypes.AWSResource, 0, len(found))
+	for _, observed := range found {
+		// The tags of the resources aren't returned by the list operation
+		if observed.ko.Spec.Name != nil {
+			tags, err := rm.sdkFindTags(ctx, observed)
+			if err != nil {
+				return nil, err
+			}
+			observed.SetTags(tags)
+		}
+		res = append(res, observed)
+	}
+	return res, nil
+}
+
 // Create attempts to create the supplied AWSResource in the backend AWS
 // service API, returning an AWSResource representing the newly-created
 // resource
@@ -95,6 +131,14 @@ func (rm *resourceManager) Create(
 	if err != nil {
 		return nil, err
 	}
+	// The create operation doesn't set the tags of the resource. If setting
+	// them fails, the next reconciliation finds the new resource and sets
+	// them again.
+	untagged := &resource{created.ko.DeepCopy()}
+	untagged.SetTags(nil)
+	if err := rm.sdkUpdateTags(ctx, r, untagged); err != nil {
+		return nil, err
+	}
 	return created, nil
 }
 
@@ -118,6 +162,18 @@ func (rm *resourceManager) Update(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+	if diffReporter.DifferentAt("Spec.Tags") {
+		if err := rm.sdkUpdateTags(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+		if !diffReporter.DifferentExcept("Spec.Tags", "Status") {
+			// The tags are the only field in the Spec that changed and the
+			// update operation doesn't change the tags
+			updated := &resource{latest.ko.DeepCopy()}
+			updated.SetTags(desired.GetTags())
+			return updated, nil
+		}
+	}
 	updated, err := rm.sdkUpdate(ctx, desired, latest, diffReporter)
 	if err != nil {
 		return nil, err
@@ -144,12 +200,13 @@ func (rm *resourceManager) Delete(
 // GetAttributes operations but all we have (for new CRs at least) is a
 // name for the resource
 func (rm *resourceManager) ARNFromName(name string) string {
-	return fmt.Sprintf(
-		"arn:aws:s3:%s:%s:%s",
+	return string(ackarn.FromTemplate(
+		"arn:{partition}:s3:::{name}",
 		rm.awsRegion,
 		rm.awsAccountID,
 		name,
-	)
+		nil,
+	))
 }
 
 // newResourceManager returns a new struct implementing
@@ -158,11 +215,8 @@ func newResourceManager(
 	rr acktypes.AWSResourceReconciler,
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
+	sess *session.Session,
 ) (*resourceManager, error) {
-	sess, err := ackrt.NewSession(region)
-	if err != nil {
-		return nil, err
-	}
 	return &resourceManager{
 		rr:           rr,
 		awsAccountID: id,
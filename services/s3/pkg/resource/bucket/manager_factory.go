@@ -20,6 +20,7 @@ import (
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+	"github.com/aws/aws-sdk-go/aws/session"
 
 	svcresource "github.com/aws/aws-controllers-k8s/services/s3/pkg/resource"
 )
@@ -28,8 +29,17 @@ import (
 // `types.AWSResourceManagerFactory` interface.
 type resourceManagerFactory struct {
 	sync.RWMutex
-	// rmCache contains resource managers for a particular AWS account ID
-	rmCache map[ackv1alpha1.AWSAccountID]*resourceManager
+	// rmCache contains resource managers for a particular AWS account ID,
+	// region and AWS session
+	rmCache map[rmCacheKey]*resourceManager
+}
+
+// rmCacheKey identifies a resource manager in a resourceManagerFactory's
+// cache
+type rmCacheKey struct {
+	id     ackv1alpha1.AWSAccountID
+	region ackv1alpha1.AWSRegion
+	sess   *session.Session
 }
 
 // ResourcePrototype returns an AWSResource that resource managers produced by
@@ -44,9 +54,11 @@ func (f *resourceManagerFactory) ManagerFor(
 	rr acktypes.AWSResourceReconciler,
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
+	sess *session.Session,
 ) (acktypes.AWSResourceManager, error) {
+	rmKey := rmCacheKey{id, region, sess}
 	f.RLock()
-	rm, found := f.rmCache[id]
+	rm, found := f.rmCache[rmKey]
 	f.RUnlock()
 
 	if found {
@@ -56,17 +68,17 @@ func (f *resourceManagerFactory) ManagerFor(
 	f.Lock()
 	defer f.Unlock()
 
-	rm, err := newResourceManager(rr, id, region)
+	rm, err := newResourceManager(rr, id, region, sess)
 	if err != nil {
 		return nil, err
 	}
-	f.rmCache[id] = rm
+	f.rmCache[rmKey] = rm
 	return rm, nil
 }
 
 func newResourceManagerFactory() *resourceManagerFactory {
 	return &resourceManagerFactory{
-		rmCache: map[ackv1alpha1.AWSAccountID]*resourceManager{},
+		rmCache: map[rmCacheKey]*resourceManager{},
 	}
 }
 
@@ -50,7 +50,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.ListBucketsWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "UNKNOWN" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -93,13 +93,42 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.ListBucketsInput{}
+	resp, err := rm.sdkapi.ListBucketsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range resp.Buckets {
+		ko := &svcapitypes.Bucket{}
+		if elem.Name != nil {
+			ko.Spec.Name = elem.Name
+		}
+
+		ok, err := rm.CustomBucketInRegion(ctx, &resource{ko})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		res = append(res, &resource{ko})
+	}
+	return res, nil
+}
+
 // sdkCreate creates the supplied resource in the backend AWS service API and
 // returns a new resource with any fields in the Status field filled in
 func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +158,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateBucketInput, error) {
 	res := &svcsdk.CreateBucketInput{}
@@ -206,3 +236,69 @@ func (rm *resourceManager) newDeleteRequestPayload(
 
 	return res, nil
 }
+
+// sdkFindTags returns the tags of the supplied resource in the backend AWS
+// service API
+func (rm *resourceManager) sdkFindTags(
+	ctx context.Context,
+	r *resource,
+) (map[string]string, error) {
+	input := &svcsdk.GetBucketTaggingInput{}
+	input.SetBucket(*r.ko.Spec.Name)
+	resp, respErr := rm.sdkapi.GetBucketTaggingWithContext(ctx, input)
+	if respErr != nil {
+		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, respErr
+	}
+	tags := map[string]string{}
+	for _, tag := range resp.TagSet {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// sdkUpdateTags adds, updates and removes tags of the supplied latest
+// resource in the backend AWS service API so that they match the tags of the
+// supplied desired resource
+func (rm *resourceManager) sdkUpdateTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	id := *latest.ko.Spec.Name
+	toAdd, toRemove := ackcompare.DiffTags(desired.GetTags(), latest.GetTags())
+	// The tag operation replaces all of the resource's tags and the untag
+	// operation removes all of them
+	if len(toAdd) > 0 || len(toRemove) > 0 {
+		toAdd = desired.GetTags()
+	}
+	if len(toAdd) == 0 && len(toRemove) > 0 {
+		input := &svcsdk.DeleteBucketTaggingInput{}
+		input.SetBucket(id)
+		_, respErr := rm.sdkapi.DeleteBucketTaggingWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	if len(toAdd) > 0 {
+		input := &svcsdk.PutBucketTaggingInput{}
+		input.SetBucket(id)
+		tags := make([]*svcsdk.Tag, 0, len(toAdd))
+		for k, v := range toAdd {
+			tag := &svcsdk.Tag{}
+			tag.SetKey(k)
+			tag.SetValue(v)
+			tags = append(tags, tag)
+		}
+		input.SetTagging(&svcsdk.Tagging{
+			TagSet: tags,
+		})
+		_, respErr := rm.sdkapi.PutBucketTaggingWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	return nil
+}
@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	_, respErr := rm.sdkapi.GetPlatformApplicationAttributesWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFound" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -80,6 +80,37 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.ListPlatformApplicationsInput{}
+	for {
+		resp, err := rm.sdkapi.ListPlatformApplicationsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.PlatformApplications {
+			ko := &svcapitypes.PlatformApplication{}
+			if elem.PlatformApplicationArn != nil {
+				if ko.Status.ACKResourceMetadata == nil {
+					ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+				}
+				tmpARN := ackv1alpha1.AWSResourceName(*elem.PlatformApplicationArn)
+				ko.Status.ACKResourceMetadata.ARN = &tmpARN
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // requiredFieldsMissingFromGetAtttributesInput returns true if there are any
 // fields for the GetAttributes Input shape that are required by not present in
 // the resource's Spec or Status
@@ -100,7 +131,7 @@ func (rm *resourceManager) newGetAttributesRequestPayload(
 	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
 		res.SetPlatformApplicationArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
 	} else {
-		res.SetPlatformApplicationArn(rm.ARNFromName(*r.ko.Spec.Name))
+		res.SetPlatformApplicationArn(rm.arnFromCR(r.ko))
 	}
 
 	return res, nil
@@ -112,7 +143,7 @@ func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +177,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreatePlatformApplicationInput, error) {
 	res := &svcsdk.CreatePlatformApplicationInput{}
@@ -215,7 +247,7 @@ func (rm *resourceManager) sdkUpdate(
 	// that desired state has been constructed from a call to GetAttributes...
 	_, respErr := rm.sdkapi.SetPlatformApplicationAttributesWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFound" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "Update")) {
 			// Technically, this means someone deleted the backend resource in
 			// between the time we got a result back from sdkFind() and here...
 			return nil, ackerr.NotFound
@@ -278,7 +310,7 @@ func (rm *resourceManager) newSetAttributesRequestPayload(
 	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
 		res.SetPlatformApplicationArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
 	} else {
-		res.SetPlatformApplicationArn(rm.ARNFromName(*r.ko.Spec.Name))
+		res.SetPlatformApplicationArn(rm.arnFromCR(r.ko))
 	}
 
 	return res, nil
@@ -307,7 +339,7 @@ func (rm *resourceManager) newDeleteRequestPayload(
 	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
 		res.SetPlatformApplicationArn(string(*r.ko.Status.ACKResourceMetadata.ARN))
 	} else {
-		res.SetPlatformApplicationArn(rm.ARNFromName(*r.ko.Spec.Name))
+		res.SetPlatformApplicationArn(rm.arnFromCR(r.ko))
 	}
 
 	return res, nil
@@ -57,7 +57,7 @@ func (rm *resourceManager) sdkFind(
 
 	resp, respErr := rm.sdkapi.GetTopicAttributesWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFound" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -89,6 +89,37 @@ func (rm *resourceManager) newListRequestPayload(
 	return res, nil
 }
 
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API, reading every page of results
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.ListTopicsInput{}
+	for {
+		resp, err := rm.sdkapi.ListTopicsWithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.Topics {
+			ko := &svcapitypes.Topic{}
+			if elem.TopicArn != nil {
+				if ko.Status.ACKResourceMetadata == nil {
+					ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+				}
+				tmpARN := ackv1alpha1.AWSResourceName(*elem.TopicArn)
+				ko.Status.ACKResourceMetadata.ARN = &tmpARN
+			}
+			res = append(res, &resource{ko})
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return res, nil
+}
+
 // requiredFieldsMissingFromGetAtttributesInput returns true if there are any
 // fields for the GetAttributes Input shape that are required by not present in
 // the resource's Spec or Status
@@ -121,7 +152,7 @@ func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +186,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.CreateTopicInput, error) {
 	res := &svcsdk.CreateTopicInput{}
@@ -220,7 +252,7 @@ func (rm *resourceManager) sdkUpdate(
 	// that desired state has been constructed from a call to GetAttributes...
 	_, respErr := rm.sdkapi.SetTopicAttributesWithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "NotFound" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "Update")) {
 			// Technically, this means someone deleted the backend resource in
 			// between the time we got a result back from sdkFind() and here...
 			return nil, ackerr.NotFound
@@ -282,3 +314,60 @@ func (rm *resourceManager) newDeleteRequestPayload(
 
 	return res, nil
 }
+
+// sdkFindTags returns the tags of the supplied resource in the backend AWS
+// service API
+func (rm *resourceManager) sdkFindTags(
+	ctx context.Context,
+	r *resource,
+) (map[string]string, error) {
+	input := &svcsdk.ListTagsForResourceInput{}
+	input.SetResourceArn(string(*r.Identifiers().ARN()))
+	resp, respErr := rm.sdkapi.ListTagsForResourceWithContext(ctx, input)
+	if respErr != nil {
+		return nil, respErr
+	}
+	tags := map[string]string{}
+	for _, tag := range resp.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// sdkUpdateTags adds, updates and removes tags of the supplied latest
+// resource in the backend AWS service API so that they match the tags of the
+// supplied desired resource
+func (rm *resourceManager) sdkUpdateTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	id := string(*latest.Identifiers().ARN())
+	toAdd, toRemove := ackcompare.DiffTags(desired.GetTags(), latest.GetTags())
+	if len(toRemove) > 0 {
+		input := &svcsdk.UntagResourceInput{}
+		input.SetResourceArn(id)
+		input.SetTagKeys(aws.StringSlice(toRemove))
+		_, respErr := rm.sdkapi.UntagResourceWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	if len(toAdd) > 0 {
+		input := &svcsdk.TagResourceInput{}
+		input.SetResourceArn(id)
+		tags := make([]*svcsdk.Tag, 0, len(toAdd))
+		for k, v := range toAdd {
+			tag := &svcsdk.Tag{}
+			tag.SetKey(k)
+			tag.SetValue(v)
+			tags = append(tags, tag)
+		}
+		input.SetTags(tags)
+		_, respErr := rm.sdkapi.TagResourceWithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	return nil
+}
@@ -34,6 +34,12 @@ type {{ .CRD.Kind }}Status struct {
 	{{- range $fieldName, $field := .CRD.StatusFields }}
 	{{ $field.Names.Camel }} {{ $field.GoType }} `json:"{{ $field.Names.CamelLower }},omitempty"`
 {{- end }}
+{{- if .CRD.HasSecretFields }}
+	// SecretsHash is the hash of the values of the Secret keys referred to
+	// by the Spec that were last applied to the backend AWS service API
+	// resource
+	SecretsHash *string `json:"secretsHash,omitempty"`
+{{- end }}
 }
 
 // {{ .CRD.Kind }} is the Schema for the {{ .CRD.Plural }} API
@@ -0,0 +1,23 @@
+{{ template "boilerplate" }}
+
+package main
+
+import (
+	"os"
+
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+{{ range $svc := .Services }}
+	{{ $svc }}controller "github.com/aws/aws-controllers-k8s/services/{{ $svc }}/pkg/controller"
+{{- end }}
+)
+
+func main() {
+	err := ackrt.Run(
+{{- range $svc := .Services }}
+		{{ $svc }}controller.New(),
+{{- end }}
+	)
+	if err != nil {
+		os.Exit(1)
+	}
+}
@@ -16,5 +16,12 @@ bases:
 # - ../crd
 - ../rbac
 - ../controller
+# Uncomment, along with webhook_patch.yaml below, to install the admission
+# webhooks. Their serving certificate is issued by cert-manager, which must be
+# installed in the cluster.
+# - ../webhook
 
 patchesStrategicMerge:
+# Uncomment to start the controller with --enable-webhooks and the serving
+# certificate of the admission webhooks mounted
+# - webhook_patch.yaml
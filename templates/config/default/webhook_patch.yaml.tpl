@@ -0,0 +1,35 @@
+# Starts the controller with the admission webhooks enabled and mounts the
+# serving certificate issued for the webhook service. The args replace the
+# args of the controller's Deployment, so keep them in sync.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ack-{{ .ServiceIDClean }}-controller
+  namespace: ack-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: controller
+        args:
+        - --aws-account-id
+        - "$(AWS_ACCOUNT_ID)"
+        - --aws-region
+        - "$(AWS_REGION)"
+        - --enable-development-logging
+        - "$(ACK_ENABLE_DEVELOPMENT_LOGGING)"
+        - --enable-webhooks
+        ports:
+        # Must match the value of the controller's --bind-port flag
+        - containerPort: 9443
+          name: webhook-server
+          protocol: TCP
+        volumeMounts:
+        # The default certificate directory of the webhook server
+        - mountPath: /tmp/k8s-webhook-server/serving-certs
+          name: webhook-cert
+          readOnly: true
+      volumes:
+      - name: webhook-cert
+        secret:
+          secretName: ack-{{ .ServiceIDClean }}-webhook-server-cert
@@ -0,0 +1,24 @@
+# The serving certificate of the webhook server, issued by cert-manager, which
+# must be installed in the cluster. cert-manager stores the certificate in the
+# Secret mounted by the controller's Deployment and injects its CA into the
+# webhook configurations.
+apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  name: ack-{{ .ServiceIDClean }}-selfsigned-issuer
+spec:
+  selfSigned: {}
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: ack-{{ .ServiceIDClean }}-webhook-serving-cert
+spec:
+  # The DNS names of the webhook service in the namespace of the controller
+  dnsNames:
+  - ack-{{ .ServiceIDClean }}-webhook-service.ack-system.svc
+  - ack-{{ .ServiceIDClean }}-webhook-service.ack-system.svc.cluster.local
+  issuerRef:
+    kind: Issuer
+    name: ack-{{ .ServiceIDClean }}-selfsigned-issuer
+  secretName: ack-{{ .ServiceIDClean }}-webhook-server-cert
@@ -0,0 +1,14 @@
+# The webhook service runs in the namespace of the controller, which is also
+# the namespace that the webhook configurations refer to. Change it along with
+# the namespace of the controller's Deployment, the DNS names in
+# certificate.yaml and the cert-manager.io/inject-ca-from annotations in
+# manifests.yaml.
+namespace: ack-system
+
+resources:
+- certificate.yaml
+- manifests.yaml
+- service.yaml
+
+configurations:
+- kustomizeconfig.yaml
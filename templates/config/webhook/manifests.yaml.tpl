@@ -0,0 +1,64 @@
+{{- $apiGroup := .APIGroup -}}
+{{- $serviceIDClean := .ServiceIDClean -}}
+{{- $apiVersion := .APIVersion -}}
+apiVersion: admissionregistration.k8s.io/v1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: ack-{{ $serviceIDClean }}-mutating-webhook-configuration
+  annotations:
+    # cert-manager sets the caBundle of the webhooks to the CA of the
+    # webhook server's certificate
+    cert-manager.io/inject-ca-from: ack-system/ack-{{ $serviceIDClean }}-webhook-serving-cert
+webhooks:
+{{- range $crd := .CRDs }}
+- name: m{{ ToLower $crd.Kind }}.{{ $apiGroup }}
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      name: ack-{{ $serviceIDClean }}-webhook-service
+      path: {{ WebhookPath "mutate" $apiGroup $crd }}
+  failurePolicy: Fail
+  sideEffects: None
+  rules:
+  - apiGroups:
+    - {{ $apiGroup }}
+    apiVersions:
+    - {{ $apiVersion }}
+    operations:
+    - CREATE
+    - UPDATE
+    resources:
+    - {{ ToLower $crd.Plural }}
+{{- end }}
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: ack-{{ $serviceIDClean }}-validating-webhook-configuration
+  annotations:
+    # cert-manager sets the caBundle of the webhooks to the CA of the
+    # webhook server's certificate
+    cert-manager.io/inject-ca-from: ack-system/ack-{{ $serviceIDClean }}-webhook-serving-cert
+webhooks:
+{{- range $crd := .CRDs }}
+- name: v{{ ToLower $crd.Kind }}.{{ $apiGroup }}
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      name: ack-{{ $serviceIDClean }}-webhook-service
+      path: {{ WebhookPath "validate" $apiGroup $crd }}
+  failurePolicy: Fail
+  sideEffects: None
+  rules:
+  - apiGroups:
+    - {{ $apiGroup }}
+    apiVersions:
+    - {{ $apiVersion }}
+    operations:
+    - CREATE
+    - UPDATE
+    resources:
+    - {{ ToLower $crd.Plural }}
+{{- end }}
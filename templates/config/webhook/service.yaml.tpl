@@ -0,0 +1,11 @@
+apiVersion: v1
+kind: Service
+metadata:
+  name: ack-{{ .ServiceIDClean }}-webhook-service
+spec:
+  ports:
+  - port: 443
+    # Must match the value of the controller's --bind-port flag
+    targetPort: 9443
+  selector:
+    control-plane: controller
@@ -4,20 +4,27 @@ package {{ .CRD.Names.Snake }}
 
 import (
 	"context"
-	"fmt"
 
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
-	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+	ackarn "github.com/aws/aws-controllers-k8s/pkg/arn"
 	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	"github.com/aws/aws-sdk-go/aws/session"
 
 	svcsdk "github.com/aws/aws-sdk-go/service/{{ .ServiceIDClean }}"
 	svcsdkapi "github.com/aws/aws-sdk-go/service/{{ .ServiceIDClean }}/{{ .ServiceIDClean }}iface"
+{{- if .CRD.ARNTemplateFields }}
+
+	svcapitypes "github.com/aws/aws-controllers-k8s/services/{{ .ServiceIDClean }}/apis/{{ .APIVersion }}"
+{{- end }}
 )
 
 // +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }},verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups={{ .APIGroup }},resources={{ ToLower .CRD.Plural }}/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // resourceManager is responsible for providing a consistent way to perform
 // CRUD operations in a backend AWS service API for Book custom resources.
@@ -63,9 +70,63 @@ func (rm *resourceManager) ReadOne(
 	if err != nil {
 		return nil, err
 	}
+{{- if .CRD.ARNTemplateFields }}
+	rm.setARNFromFields(observed)
+{{- end }}
+{{- if .CRD.TagOps }}
+	// The tags of the resource aren't returned by the read operation
+{{- if .CRD.TagOps.List.IdentifierIsName }}
+	if observed.ko.Spec.{{ .CRD.SpecNameField.Names.Camel }} != nil {
+{{- else }}
+	if observed.Identifiers().ARN() != nil {
+{{- end }}
+		tags, err := rm.sdkFindTags(ctx, observed)
+		if err != nil {
+			return nil, err
+		}
+		observed.SetTags(tags)
+	}
+{{- end }}
 	return observed, nil
 }
 
+{{- if .CRD.CanReadAll }}
+
+// ReadMany returns the currently-observed state of all the resources of this
+// kind in the backend AWS service API, in the resource manager's AWS account
+// and region
+func (rm *resourceManager) ReadMany(
+	ctx context.Context,
+) ([]acktypes.AWSResource, error) {
+	found, err := rm.sdkFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]acktypes.AWSResource, 0, len(found))
+	for _, observed := range found {
+{{- if .CRD.ARNTemplateFields }}
+		rm.setARNFromFields(observed)
+{{- end }}
+{{- if .CRD.TagOps }}
+		// The tags of the resources aren't returned by the list operation
+{{- if .CRD.TagOps.List.IdentifierIsName }}
+		if observed.ko.Spec.{{ .CRD.SpecNameField.Names.Camel }} != nil {
+{{- else }}
+		if observed.Identifiers().ARN() != nil {
+{{- end }}
+			tags, err := rm.sdkFindTags(ctx, observed)
+			if err != nil {
+				return nil, err
+			}
+			observed.SetTags(tags)
+		}
+{{- end }}
+		res = append(res, observed)
+	}
+	return res, nil
+}
+{{- end }}
+
 // Create attempts to create the supplied AWSResource in the backend AWS
 // service API, returning an AWSResource representing the newly-created
 // resource
@@ -82,6 +143,19 @@ func (rm *resourceManager) Create(
 	if err != nil {
 		return nil, err
 	}
+{{- if .CRD.ARNTemplateFields }}
+	rm.setARNFromFields(created)
+{{- end }}
+{{- if and .CRD.TagOps (not .CRD.CreateSetsTags) }}
+	// The create operation doesn't set the tags of the resource. If setting
+	// them fails, the next reconciliation finds the new resource and sets
+	// them again.
+	untagged := &resource{created.ko.DeepCopy()}
+	untagged.SetTags(nil)
+	if err := rm.sdkUpdateTags(ctx, r, untagged); err != nil {
+		return nil, err
+	}
+{{- end }}
 	return created, nil
 }
 
@@ -105,6 +179,21 @@ func (rm *resourceManager) Update(
 		// Should never happen... if it does, it's buggy code.
 		panic("resource manager's Update() method received resource with nil CR object")
 	}
+{{- if .CRD.TagOps }}
+{{- $tagPath := printf "Spec.%s" .CRD.TagField.Names.Camel }}
+	if diffReporter.DifferentAt("{{ $tagPath }}") {
+		if err := rm.sdkUpdateTags(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+		if !diffReporter.DifferentExcept("{{ $tagPath }}", "Status") {
+			// The tags are the only field in the Spec that changed and the
+			// update operation doesn't change the tags
+			updated := &resource{latest.ko.DeepCopy()}
+			updated.SetTags(desired.GetTags())
+			return updated, nil
+		}
+	}
+{{- end }}
 	updated, err := rm.sdkUpdate(ctx, desired, latest, diffReporter)
 	if err != nil {
 		return nil, err
@@ -130,26 +219,95 @@ func (rm *resourceManager) Delete(
 // is useful for constructing ARNs for APIs that require ARNs in their
 // GetAttributes operations but all we have (for new CRs at least) is a
 // name for the resource
+{{- if not .CRD.ARNTemplateFields }}
 func (rm *resourceManager) ARNFromName(name string) string {
-	return fmt.Sprintf(
-		"arn:aws:{{ .ServiceIDClean }}:%s:%s:%s",
+	return string(ackarn.FromTemplate(
+		"{{ .CRD.ARNTemplate }}",
 		rm.awsRegion,
 		rm.awsAccountID,
 		name,
-	)
+		nil,
+	))
+}
+{{- else if .CRD.ARNTemplateNameOnlyField }}
+func (rm *resourceManager) ARNFromName(name string) string {
+	return string(ackarn.FromTemplate(
+		"{{ .CRD.ARNTemplate }}",
+		rm.awsRegion,
+		rm.awsAccountID,
+		"",
+		map[string]string{
+			"{{ .CRD.ARNTemplateNameOnlyField.Name }}": name,
+		},
+	))
+}
+{{- else }}
+//
+// The ARN of this resource contains the values of fields other than its
+// name, so it can't be built from the name alone and the empty string is
+// returned.
+func (rm *resourceManager) ARNFromName(name string) string {
+	return ""
+}
+{{- end }}
+{{- if .CRD.ARNTemplateFields }}
+
+// arnFromCR returns the AWS Resource Name of the supplied CR, built from the
+// CR's fields named by the placeholders in the resource's ARN template, or
+// the empty string if any of those fields isn't set yet
+func (rm *resourceManager) arnFromCR(ko *svcapitypes.{{ .CRD.Names.Camel }}) string {
+{{- $nameField := .CRD.ARNTemplateNameField }}
+{{- if $nameField }}
+	if ko.Spec.{{ $nameField.Names.Camel }} == nil {
+		return ""
+	}
+{{- end }}
+{{- range $field := .CRD.ARNTemplateFields }}
+	if ko.{{ $field.Path }} == nil {
+		return ""
+	}
+{{- end }}
+	return string(ackarn.FromTemplate(
+		"{{ .CRD.ARNTemplate }}",
+		rm.awsRegion,
+		rm.awsAccountID,
+		{{ if $nameField }}*ko.Spec.{{ $nameField.Names.Camel }}{{ else }}""{{ end }},
+		map[string]string{
+{{- range $field := .CRD.ARNTemplateFields }}
+			"{{ $field.Name }}": *ko.{{ $field.Path }},
+{{- end }}
+		},
+	))
 }
 
+// setARNFromFields sets the AWS Resource Name of the supplied resource from
+// the resource's fields when the backend AWS service API doesn't return it
+func (rm *resourceManager) setARNFromFields(r *resource) {
+	ko := r.ko
+	if ko.Status.ACKResourceMetadata != nil &&
+		ko.Status.ACKResourceMetadata.ARN != nil {
+		return
+	}
+	arn := rm.arnFromCR(ko)
+	if arn == "" {
+		return
+	}
+	if ko.Status.ACKResourceMetadata == nil {
+		ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+	}
+	tmpARN := ackv1alpha1.AWSResourceName(arn)
+	ko.Status.ACKResourceMetadata.ARN = &tmpARN
+}
+{{- end }}
+
 // newResourceManager returns a new struct implementing
 // acktypes.AWSResourceManager
 func newResourceManager(
 	rr acktypes.AWSResourceReconciler,
 	id ackv1alpha1.AWSAccountID,
 	region ackv1alpha1.AWSRegion,
+	sess *session.Session,
 ) (*resourceManager, error) {
-	sess, err := ackrt.NewSession(region)
-	if err != nil {
-		return nil, err
-	}
 	return &resourceManager{
 		rr: rr,
 		awsAccountID: id,
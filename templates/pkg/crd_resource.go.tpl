@@ -3,6 +3,9 @@
 package {{ .CRD.Names.Snake }}
 
 import (
+{{- if and .CRD.TagField (not .CRD.TagFieldIsMap) }}
+	"sort"
+{{ end }}
 	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,3 +57,174 @@ func (r *resource) RuntimeMetaObject() acktypes.RuntimeMetaObject {
 func (r *resource) Conditions() []*ackv1alpha1.Condition {
 	return r.ko.Status.Conditions
 }
+
+// SetConditions replaces the ACK Conditions collection for the AWSResource
+func (r *resource) SetConditions(conditions []*ackv1alpha1.Condition) {
+	r.ko.Status.Conditions = conditions
+}
+{{- if .CRD.StateField }}
+
+// State returns the current state of the backend AWS service API resource
+func (r *resource) State() string {
+{{ GoCodeState .CRD "r.ko" 1 -}}
+}
+
+// IsStable returns true if the backend AWS service API resource is in a state
+// in which it can be used and modified
+func (r *resource) IsStable() bool {
+	switch r.State() {
+	case {{ range $i, $v := .CRD.StateConfig.StableValues }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}:
+		return true
+	}
+	return false
+}
+
+// IsFailed returns true if the backend AWS service API resource is in a
+// terminal state from which it will not recover
+func (r *resource) IsFailed() bool {
+{{- if .CRD.StateConfig.FailedValues }}
+	switch r.State() {
+	case {{ range $i, $v := .CRD.StateConfig.FailedValues }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}:
+		return true
+	}
+{{- end }}
+	return false
+}
+{{- end }}
+{{- if .CRD.SpecNameField }}
+{{- $nameField := .CRD.SpecNameField.Names.Camel }}
+
+// ResourceName returns the name of the backend AWS service API resource, or
+// the empty string if the name isn't known
+func (r *resource) ResourceName() string {
+	if r.ko.Spec.{{ $nameField }} == nil {
+		return ""
+	}
+	return *r.ko.Spec.{{ $nameField }}
+}
+
+// SetResourceName sets the name of the backend AWS service API resource
+func (r *resource) SetResourceName(name string) {
+	r.ko.Spec.{{ $nameField }} = &name
+}
+{{- end }}
+{{- if .CRD.ChildKinds }}
+
+// ChildKinds returns the kinds of the resources whose backend AWS service API
+// resources are contained in the resource's, in the order they're deleted
+func (r *resource) ChildKinds() []string {
+	return []string{
+{{- range $kind := .CRD.ChildKinds }}
+		"{{ $kind }}",
+{{- end }}
+	}
+}
+
+// ChildIdentifier returns the identifier by which the child resources refer
+// to the backend AWS service API resource, or the empty string if it isn't
+// known
+func (r *resource) ChildIdentifier() string {
+	if r.ko.{{ .CRD.ChildIdentifierPath }} == nil {
+		return ""
+	}
+	return *r.ko.{{ .CRD.ChildIdentifierPath }}
+}
+{{- end }}
+{{- if .CRD.ParentKind }}
+{{- $parentField := .CRD.ParentIdentifierField.Names.Camel }}
+
+// ParentKind returns the kind of the resource whose backend AWS service API
+// resource contains the resource's
+func (r *resource) ParentKind() string {
+	return "{{ .CRD.ParentKind }}"
+}
+
+// ParentIdentifier returns the identifier of the parent's backend AWS service
+// API resource, or the empty string if it isn't known
+func (r *resource) ParentIdentifier() string {
+	if r.ko.Spec.{{ $parentField }} == nil {
+		return ""
+	}
+	return *r.ko.Spec.{{ $parentField }}
+}
+{{- end }}
+{{- if .CRD.HasSecretFields }}
+
+// SecretReferences returns the references to Secret keys in the AWSResource's
+// Spec, keyed by the path of the Spec field
+func (r *resource) SecretReferences() map[string]*ackv1alpha1.SecretKeyReference {
+	refs := map[string]*ackv1alpha1.SecretKeyReference{}
+{{- range $field := .CRD.SecretFields }}
+	if r.ko.Spec.{{ $field.Names.Camel }} != nil {
+		refs["Spec.{{ $field.Names.Camel }}"] = r.ko.Spec.{{ $field.Names.Camel }}
+	}
+{{- end }}
+	return refs
+}
+
+// SecretsHash returns the hash of the referenced Secret values that were last
+// applied to the backend AWS service API resource
+func (r *resource) SecretsHash() string {
+	if r.ko.Status.SecretsHash == nil {
+		return ""
+	}
+	return *r.ko.Status.SecretsHash
+}
+
+// SetSecretsHash records the hash of the referenced Secret values applied to
+// the backend AWS service API resource
+func (r *resource) SetSecretsHash(hash string) {
+	r.ko.Status.SecretsHash = &hash
+}
+{{- end }}
+{{- if .CRD.TagField }}
+{{- $tagField := .CRD.TagField.Names.Camel }}
+
+// GetTags returns the tags of the AWSResource as a map of tag keys to tag
+// values
+func (r *resource) GetTags() map[string]string {
+	tags := map[string]string{}
+{{- if .CRD.TagFieldIsMap }}
+	for k, v := range r.ko.Spec.{{ $tagField }} {
+		if v != nil {
+			tags[k] = *v
+		}
+	}
+{{- else }}
+	for _, tag := range r.ko.Spec.{{ $tagField }} {
+		if tag != nil && tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+{{- end }}
+	return tags
+}
+
+// SetTags replaces the tags of the AWSResource with the supplied map of tag
+// keys to tag values
+func (r *resource) SetTags(tags map[string]string) {
+{{- if .CRD.TagFieldIsMap }}
+	r.ko.Spec.{{ $tagField }} = make(map[string]*string, len(tags))
+	for k, v := range tags {
+		v := v
+		r.ko.Spec.{{ $tagField }}[k] = &v
+	}
+{{- else }}
+	// Tags are sorted by key so that resources with the same tags are equal
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	r.ko.Spec.{{ $tagField }} = make([]*svcapitypes.{{ .CRD.TagField.GoTypeElem }}, 0, len(keys))
+	for _, k := range keys {
+		k := k
+		v := tags[k]
+		r.ko.Spec.{{ $tagField }} = append(
+			r.ko.Spec.{{ $tagField }},
+			&svcapitypes.{{ .CRD.TagField.GoTypeElem }}{Key: &k, Value: &v},
+		)
+	}
+{{- end }}
+}
+{{- end }}
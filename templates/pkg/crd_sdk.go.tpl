@@ -51,7 +51,7 @@ func (rm *resourceManager) sdkFind(
 {{ $setCode := GoCodeSetReadOneOutput .CRD "resp" "ko.Status" 1 }}
 	{{ if not ( Empty $setCode ) }}resp{{ else }}_{{ end }}, respErr := rm.sdkapi.{{ .CRD.Ops.ReadOne.Name }}WithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "{{ ResourceExceptionCode .CRD 404 }}" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, err
@@ -77,7 +77,7 @@ func (rm *resourceManager) sdkFind(
 {{ $setCode := GoCodeGetAttributesSetOutput .CRD "resp" "ko.Status" 1 }}
 	{{ if not ( Empty $setCode ) }}resp{{ else }}_{{ end }}, respErr := rm.sdkapi.{{ .CRD.Ops.GetAttributes.Name }}WithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "{{ ResourceExceptionCode .CRD 404 }}" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -96,7 +96,7 @@ func (rm *resourceManager) sdkFind(
 {{ $setCode := GoCodeSetReadManyOutput .CRD "resp" "ko" 1 }}
 	{{ if not ( Empty $setCode ) }}resp{{ else }}_{{ end }}, respErr := rm.sdkapi.{{ .CRD.Ops.ReadMany.Name }}WithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "{{ ResourceExceptionCode .CRD 404 }}" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "ReadOne")) {
 			return nil, ackerr.NotFound
 		}
 		return nil, respErr
@@ -152,6 +152,65 @@ func (rm *resourceManager) newListRequestPayload(
 }
 {{- end }}
 
+{{- if .CRD.CanReadAll }}
+{{- $pagination := .CRD.ReadManyPagination }}
+{{- $filterMethod := .CRD.ReadManyFilterCustomMethodName }}
+// sdkFindAll returns all the resources of this kind in the backend AWS
+// service API{{ if $pagination }}, reading every page of results{{ end }}
+func (rm *resourceManager) sdkFindAll(
+	ctx context.Context,
+) ([]*resource, error) {
+	res := []*resource{}
+	input := &svcsdk.{{ .CRD.Ops.ReadMany.InputRef.Shape.ShapeName }}{}
+{{- if $pagination }}
+	for {
+		resp, err := rm.sdkapi.{{ .CRD.Ops.ReadMany.Name }}WithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range resp.{{ .CRD.ReadManyListMemberName }} {
+			ko := &svcapitypes.{{ .CRD.Names.Camel }}{}
+{{ GoCodeSetReadManyElemOutput .CRD "ko" 3 -}}
+{{- if $filterMethod }}
+			ok, err := rm.{{ $filterMethod }}(ctx, &resource{ko})
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+{{ end -}}
+			res = append(res, &resource{ko})
+		}
+		if resp.{{ $pagination.OutputTokenMember }} == nil || *resp.{{ $pagination.OutputTokenMember }} == "" {
+			break
+		}
+		input.{{ $pagination.InputTokenMember }} = resp.{{ $pagination.OutputTokenMember }}
+	}
+{{- else }}
+	resp, err := rm.sdkapi.{{ .CRD.Ops.ReadMany.Name }}WithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range resp.{{ .CRD.ReadManyListMemberName }} {
+		ko := &svcapitypes.{{ .CRD.Names.Camel }}{}
+{{ GoCodeSetReadManyElemOutput .CRD "ko" 2 -}}
+{{- if $filterMethod }}
+		ok, err := rm.{{ $filterMethod }}(ctx, &resource{ko})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+{{ end -}}
+		res = append(res, &resource{ko})
+	}
+{{- end }}
+	return res, nil
+}
+{{- end }}
+
 {{- if .CRD.Ops.GetAttributes }}
 // requiredFieldsMissingFromGetAtttributesInput returns true if there are any
 // fields for the GetAttributes Input shape that are required by not present in
@@ -179,7 +238,7 @@ func (rm *resourceManager) sdkCreate(
 	ctx context.Context,
 	r *resource,
 ) (*resource, error) {
-	input, err := rm.newCreateRequestPayload(r)
+	input, err := rm.newCreateRequestPayload(ctx, r)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +268,7 @@ func (rm *resourceManager) sdkCreate(
 // newCreateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Create API call for the resource
 func (rm *resourceManager) newCreateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.{{ .CRD.Ops.Create.InputRef.Shape.ShapeName }}, error) {
 	res := &svcsdk.{{ .CRD.Ops.Create.InputRef.Shape.ShapeName }}{}
@@ -234,7 +294,7 @@ func (rm *resourceManager) sdkUpdate(
 	}
 {{ end }}
 
-	input, err := rm.newUpdateRequestPayload(desired)
+	input, err := rm.newUpdateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
@@ -272,7 +332,7 @@ func (rm *resourceManager) sdkUpdate(
 	// that desired state has been constructed from a call to GetAttributes...
 	_, respErr := rm.sdkapi.{{ .CRD.Ops.SetAttributes.Name }}WithContext(ctx, input)
 	if respErr != nil {
-		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "{{ ResourceExceptionCode .CRD 404 }}" {
+		if ackerr.IsNotFound(ackerr.Wrap(respErr, &resourceGK, "Update")) {
 			// Technically, this means someone deleted the backend resource in
 			// between the time we got a result back from sdkFind() and here...
 			return nil, ackerr.NotFound
@@ -294,6 +354,7 @@ func (rm *resourceManager) sdkUpdate(
 // newUpdateRequestPayload returns an SDK-specific struct for the HTTP request
 // payload of the Update API call for the resource
 func (rm *resourceManager) newUpdateRequestPayload(
+	ctx context.Context,
 	r *resource,
 ) (*svcsdk.{{ .CRD.Ops.Update.InputRef.Shape.ShapeName }}, error) {
 	res := &svcsdk.{{ .CRD.Ops.Update.InputRef.Shape.ShapeName }}{}
@@ -352,3 +413,110 @@ func (rm *resourceManager) newDeleteRequestPayload(
 	return res, nil
 }
 {{- end -}}
+{{- if .CRD.TagOps }}
+{{- $listOp := .CRD.TagOps.List }}
+{{- $tagOp := .CRD.TagOps.Tag }}
+{{- $untagOp := .CRD.TagOps.Untag }}
+
+// sdkFindTags returns the tags of the supplied resource in the backend AWS
+// service API
+func (rm *resourceManager) sdkFindTags(
+	ctx context.Context,
+	r *resource,
+) (map[string]string, error) {
+	input := &svcsdk.{{ $listOp.Operation.InputRef.Shape.ShapeName }}{}
+{{- if $listOp.IdentifierIsName }}
+	input.Set{{ $listOp.IdentifierMember }}(*r.ko.Spec.{{ .CRD.SpecNameField.Names.Camel }})
+{{- else }}
+	input.Set{{ $listOp.IdentifierMember }}(string(*r.Identifiers().ARN()))
+{{- end }}
+	resp, respErr := rm.sdkapi.{{ $listOp.Operation.Name }}WithContext(ctx, input)
+	if respErr != nil {
+{{- if .CRD.TagOps.NoTagsErrorCode }}
+		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == "{{ .CRD.TagOps.NoTagsErrorCode }}" {
+			return map[string]string{}, nil
+		}
+{{- end }}
+		return nil, respErr
+	}
+	tags := map[string]string{}
+{{- if $listOp.TagsIsMap }}
+	for k, v := range resp.{{ $listOp.TagsMember }} {
+		tags[k] = aws.StringValue(v)
+	}
+{{- else }}
+	for _, tag := range resp.{{ $listOp.TagsMember }} {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+{{- end }}
+	return tags, nil
+}
+
+// sdkUpdateTags adds, updates and removes tags of the supplied latest
+// resource in the backend AWS service API so that they match the tags of the
+// supplied desired resource
+func (rm *resourceManager) sdkUpdateTags(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+{{- if $listOp.IdentifierIsName }}
+	id := *latest.ko.Spec.{{ .CRD.SpecNameField.Names.Camel }}
+{{- else }}
+	id := string(*latest.Identifiers().ARN())
+{{- end }}
+	toAdd, toRemove := ackcompare.DiffTags(desired.GetTags(), latest.GetTags())
+{{- if $untagOp.RemovesAllTags }}
+	// The tag operation replaces all of the resource's tags and the untag
+	// operation removes all of them
+	if len(toAdd) > 0 || len(toRemove) > 0 {
+		toAdd = desired.GetTags()
+	}
+	if len(toAdd) == 0 && len(toRemove) > 0 {
+		input := &svcsdk.{{ $untagOp.Operation.InputRef.Shape.ShapeName }}{}
+		input.Set{{ $untagOp.IdentifierMember }}(id)
+		_, respErr := rm.sdkapi.{{ $untagOp.Operation.Name }}WithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+{{- else }}
+	if len(toRemove) > 0 {
+		input := &svcsdk.{{ $untagOp.Operation.InputRef.Shape.ShapeName }}{}
+		input.Set{{ $untagOp.IdentifierMember }}(id)
+		input.Set{{ $untagOp.TagsMember }}(aws.StringSlice(toRemove))
+		_, respErr := rm.sdkapi.{{ $untagOp.Operation.Name }}WithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+{{- end }}
+	if len(toAdd) > 0 {
+		input := &svcsdk.{{ $tagOp.Operation.InputRef.Shape.ShapeName }}{}
+		input.Set{{ $tagOp.IdentifierMember }}(id)
+{{- if $tagOp.TagsIsMap }}
+		tags := aws.StringMap(toAdd)
+{{- else }}
+		tags := make([]*svcsdk.{{ $tagOp.TagShapeName }}, 0, len(toAdd))
+		for k, v := range toAdd {
+			tag := &svcsdk.{{ $tagOp.TagShapeName }}{}
+			tag.SetKey(k)
+			tag.SetValue(v)
+			tags = append(tags, tag)
+		}
+{{- end }}
+{{- if $tagOp.TagsContainerMember }}
+		input.Set{{ $tagOp.TagsContainerMember }}(&svcsdk.{{ $tagOp.TagsContainerShapeName }}{
+			{{ $tagOp.TagsMember }}: tags,
+		})
+{{- else }}
+		input.Set{{ $tagOp.TagsMember }}(tags)
+{{- end }}
+		_, respErr := rm.sdkapi.{{ $tagOp.Operation.Name }}WithContext(ctx, input)
+		if respErr != nil {
+			return respErr
+		}
+	}
+	return nil
+}
+{{- end }}
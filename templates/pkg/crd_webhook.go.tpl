@@ -0,0 +1,52 @@
+{{ template "boilerplate" }}
+
+package {{ .CRD.Names.Snake }}
+
+import (
+	"regexp"
+
+	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	svcapitypes "github.com/aws/aws-controllers-k8s/services/{{ .ServiceIDClean }}/apis/{{ .APIVersion }}"
+)
+
+// Hack to avoid import errors during build...
+var (
+	_ = regexp.MustCompile
+	_ = aws.String
+	_ = arn.IsARN
+	_ = &svcapitypes.{{ .CRD.Names.Camel }}{}
+)
+{{- $patterns := GoCodeValidationPatterns .CRD 1 }}
+{{- if $patterns }}
+
+// Patterns that the values of Spec fields must match, compiled once
+var (
+{{ $patterns -}}
+)
+{{- end }}
+
+// Default sets default values for any fields in the supplied AWSResource's
+// Spec that have not been set by the Kubernetes user
+func (d *resourceDescriptor) Default(
+	res acktypes.AWSResource,
+) {
+	ko := res.(*resource).ko
+	_ = ko
+{{ GoCodeDefaultSpec .CRD "ko" 1 -}}
+}
+
+// Validate returns an error describing all the fields in the supplied
+// AWSResource's Spec whose values are not acceptable to the backend AWS
+// service API, or nil if the Spec is valid
+func (d *resourceDescriptor) Validate(
+	res acktypes.AWSResource,
+) error {
+	ko := res.(*resource).ko
+	errs := field.ErrorList{}
+{{ GoCodeValidateSpec .CRD "ko" "errs" 1 -}}
+	return errs.ToAggregate()
+}
@@ -3,6 +3,9 @@
 package resource
 
 import (
+{{- if .ErrorCodes }}
+	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+{{- end }}
 	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
 	acktypes "github.com/aws/aws-controllers-k8s/pkg/types"
 )
@@ -10,6 +13,19 @@ import (
 var (
 	reg = ackrt.NewRegistry()
 )
+{{- if .ErrorCodes }}
+
+func init() {
+{{- range $class, $codes := .ErrorCodes }}
+	ackerr.RegisterErrorCodes(
+		"{{ $.APIGroup }}", ackerr.{{ $class }},
+{{- range $code := $codes }}
+		"{{ $code }}",
+{{- end }}
+	)
+{{- end }}
+}
+{{- end }}
 
 // GetManagerFactories returns a slice of resource manager factories that are
 // registered with this package
@@ -0,0 +1,30 @@
+{{ template "boilerplate" }}
+
+package controller
+
+import (
+	ackrt "github.com/aws/aws-controllers-k8s/pkg/runtime"
+
+	svctypes "github.com/aws/aws-controllers-k8s/services/{{ .ServiceIDClean }}/apis/{{ .APIVersion }}"
+	svcresource "github.com/aws/aws-controllers-k8s/services/{{ .ServiceIDClean }}/pkg/resource"
+
+	{{ $serviceIDClean := .ServiceIDClean }} {{range $crdName := .SnakeCasedCRDNames }}_ "github.com/aws/aws-controllers-k8s/services/{{ $serviceIDClean }}/pkg/resource/{{ $crdName }}"
+	{{end}}
+)
+
+const (
+	awsServiceAPIGroup = "{{ .APIGroup }}"
+	awsServiceAlias    = "{{ .ServiceIDClean }}"
+)
+
+// New returns a service controller managing all the resources of the
+// {{ .ServiceID }} service API
+func New() *ackrt.ServiceController {
+	return ackrt.NewServiceController(
+		awsServiceAlias, awsServiceAPIGroup,
+	).WithSchemeBuilder(
+		svctypes.AddToScheme,
+	).WithResourceManagerFactories(
+		svcresource.GetManagerFactories(),
+	)
+}